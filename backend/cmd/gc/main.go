@@ -0,0 +1,46 @@
+// Command gc finds stored files that nothing in the database refers to any
+// more and, with -dry-run=false, deletes those older than the grace period.
+// By default it only reports what it would delete.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/joho/godotenv"
+	postgres "github.com/pur108/ebook-platform/backend/internal/repository/supabase"
+	"github.com/pur108/ebook-platform/backend/internal/storage"
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", true, "report unreferenced files without deleting them")
+	grace := flag.Duration("grace", usecase.DefaultStorageGCGrace, "leave files modified more recently than this alone")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	db := postgres.NewDB()
+	gcUsecase := usecase.NewStorageGCUsecase(storage.NewStorage(), postgres.NewBlobRepository(db))
+
+	report, err := gcUsecase.CollectGarbage(*grace, *dryRun)
+	if report != nil {
+		for _, obj := range report.Garbage {
+			fmt.Printf("%s\t%d\t%s\n", obj.Key, obj.Size, obj.ModTime.Format("2006-01-02 15:04:05"))
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Scanned %d files: %d unreferenced (%d bytes), %d unreferenced blobs\n",
+		report.Scanned, len(report.Garbage), report.GarbageBytes, report.Blobs)
+	if *dryRun {
+		fmt.Println("Dry run: nothing was deleted. Re-run with -dry-run=false to delete.")
+	} else {
+		fmt.Printf("Deleted %d files\n", report.Deleted)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	postgres "github.com/pur108/ebook-platform/backend/internal/repository/supabase"
+	"github.com/pur108/ebook-platform/backend/internal/storage"
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+// import_chapter creates chapters from a CBZ/ZIP archive on disk, e.g.
+//
+//	go run ./cmd/import_chapter -season <season-id> -archive chapter12.cbz
+func main() {
+	seasonFlag := flag.String("season", "", "ID of the season to import into")
+	archivePath := flag.String("archive", "", "path to the CBZ/ZIP archive")
+	number := flag.Int("number", 0, "chapter number if the archive has no ComicInfo.xml")
+	title := flag.String("title", "", "chapter title if the archive has no ComicInfo.xml")
+	status := flag.String("status", string(domain.ChapterDraft), "status of the new chapters (draft or published)")
+	flag.Parse()
+
+	seasonID, err := uuid.Parse(*seasonFlag)
+	if err != nil || *archivePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+	db := postgres.NewDB()
+
+	f, err := os.Open(*archivePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	limits, err := usecase.UploadLimitsFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	comicRepo := postgres.NewComicRepository(db)
+	uploadUsecase := usecase.NewUploadUsecase(storage.NewStorage(), postgres.NewBlobRepository(db), limits)
+	importUsecase := usecase.NewImportUsecase(comicRepo, uploadUsecase)
+
+	// The operator running this tool acts with admin rights.
+	admin := usecase.Requester{Role: domain.RoleAdmin}
+	chapters, err := importUsecase.ImportChapters(admin, seasonID, f, info.Size(), usecase.ChapterInput{
+		ChapterNumber: *number,
+		Title:         *title,
+		Status:        domain.ChapterStatus(*status),
+	})
+	if err != nil {
+		log.Fatal("Import failed: ", err)
+	}
+
+	for _, c := range chapters {
+		fmt.Printf("Imported chapter %d %q (%s) with %d pages\n", c.ChapterNumber, c.Title, c.ID, len(c.Images))
+	}
+}
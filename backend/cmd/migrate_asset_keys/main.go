@@ -0,0 +1,138 @@
+// Command migrate_asset_keys rewrites image URLs stored in the database into
+// storage keys, which the server resolves against ASSET_BASE_URL at
+// response time. URLs that don't start with one of the given prefixes, such
+// as images hosted elsewhere, are left alone. It is safe to run repeatedly.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	postgres "github.com/pur108/ebook-platform/backend/internal/repository/supabase"
+	"gorm.io/gorm"
+)
+
+var urlColumns = []struct{ table, column string }{
+	{"series", "thumbnail_url"},
+	{"series", "cover_image_url"},
+	{"series", "banner_image_url"},
+	{"chapter_images", "image_url"},
+	{"chapter_images", "thumbnail_url"},
+}
+
+func main() {
+	prefixFlag := flag.String("prefix", "http://localhost:8080/uploads/",
+		"comma-separated URL prefixes to strip, e.g. every base URL uploads were served from")
+	dryRun := flag.Bool("dry-run", false, "report what would change without writing")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	var prefixes []string
+	for _, p := range strings.Split(*prefixFlag, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			prefixes = append(prefixes, strings.TrimRight(p, "/")+"/")
+		}
+	}
+	if len(prefixes) == 0 {
+		log.Fatal("-prefix is required")
+	}
+
+	db := postgres.NewDB()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, prefix := range prefixes {
+			for _, c := range urlColumns {
+				// Identifiers come from the fixed list above.
+				where := fmt.Sprintf("left(%s, length(?)) = ?", c.column)
+				if *dryRun {
+					var n int64
+					if err := tx.Table(c.table).Where(where, prefix, prefix).Count(&n).Error; err != nil {
+						return err
+					}
+					fmt.Printf("%s.%s: %d rows under %s\n", c.table, c.column, n, prefix)
+					continue
+				}
+				res := tx.Exec(
+					fmt.Sprintf("UPDATE %s SET %s = substr(%s, length(?) + 1) WHERE %s", c.table, c.column, c.column, where),
+					prefix, prefix, prefix,
+				)
+				if res.Error != nil {
+					return res.Error
+				}
+				fmt.Printf("%s.%s: rewrote %d rows under %s\n", c.table, c.column, res.RowsAffected, prefix)
+			}
+		}
+		return migrateVariants(tx, prefixes, *dryRun)
+	})
+	if err != nil {
+		log.Fatal("Migration failed: ", err)
+	}
+	if *dryRun {
+		fmt.Println("Dry run; nothing was written.")
+	}
+}
+
+// migrateVariants rewrites the per-variant URLs inside chapter_images.variants.
+func migrateVariants(tx *gorm.DB, prefixes []string, dryRun bool) error {
+	var rows []struct {
+		ID       uuid.UUID
+		Variants string
+	}
+	err := tx.Table("chapter_images").
+		Select("id, variants::text AS variants").
+		Where("variants IS NOT NULL AND variants::text LIKE ?", `%"url"%`).
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	changed := 0
+	for _, row := range rows {
+		var variants []map[string]interface{}
+		if err := json.Unmarshal([]byte(row.Variants), &variants); err != nil {
+			return fmt.Errorf("chapter image %s: %w", row.ID, err)
+		}
+		dirty := false
+		for _, v := range variants {
+			url, _ := v["url"].(string)
+			if url == "" {
+				continue
+			}
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(url, prefix) {
+					v["key"] = strings.TrimPrefix(url, prefix)
+					delete(v, "url")
+					dirty = true
+					break
+				}
+			}
+		}
+		if !dirty {
+			continue
+		}
+		changed++
+		if dryRun {
+			continue
+		}
+
+		data, err := json.Marshal(variants)
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&domain.ChapterImage{}).Where("id = ?", row.ID).
+			Update("variants", gorm.Expr("?::jsonb", string(data))).Error
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("chapter_images.variants: rewrote %d rows\n", changed)
+	return nil
+}
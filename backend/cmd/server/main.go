@@ -1,17 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/joho/godotenv"
 	httpDelivery "github.com/pur108/ebook-platform/backend/internal/delivery/http"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
 	postgres "github.com/pur108/ebook-platform/backend/internal/repository/supabase"
+	"github.com/pur108/ebook-platform/backend/internal/storage"
 	"github.com/pur108/ebook-platform/backend/internal/usecase"
+	"github.com/pur108/ebook-platform/backend/internal/worker"
 )
 
 func main() {
@@ -26,34 +32,112 @@ func main() {
 	db := postgres.NewDB()
 
 	// Initialize Fiber
-	app := fiber.New()
+	// Chapter archives and page sets are far larger than Fiber's 4 MB default.
+	bodyLimit := 256 << 20
+	if v := os.Getenv("MAX_REQUEST_BYTES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			log.Fatal("Invalid MAX_REQUEST_BYTES: ", err)
+		}
+		bodyLimit = n
+	}
+	app := fiber.New(fiber.Config{BodyLimit: bodyLimit})
 
 	// Middleware
 	app.Use(logger.New())
-	app.Use(cors.New())
+	app.Use(cors.New(cors.Config{ExposeHeaders: httpDelivery.TusExposedHeaders()}))
+
+	// File storage. The database holds storage keys; responses resolve them
+	// against ASSET_BASE_URL (e.g. a CDN), defaulting to the storage's own.
+	fileStorage := storage.NewStorage()
+	assetBaseURL := os.Getenv("ASSET_BASE_URL")
+	if assetBaseURL == "" {
+		assetBaseURL = fileStorage.URL("")
+	}
+	domain.SetAssetBaseURL(assetBaseURL)
 
 	// Repositories
 	userRepo := postgres.NewUserRepository(db)
 	comicRepo := postgres.NewComicRepository(db)
 	layerRepo := postgres.NewLayerRepository(db)
+	tagRepo := postgres.NewTagRepository(db)
+	searchRepo := postgres.NewSearchRepository(db)
+	uploadSessionRepo := postgres.NewUploadSessionRepository(db)
+	blobRepo := postgres.NewBlobRepository(db)
 
 	// Usecases
 	authUsecase := usecase.NewAuthUsecase(userRepo)
 	userUsecase := usecase.NewUserUsecase(userRepo)
-	comicUsecase := usecase.NewComicUsecase(comicRepo)
-	layerUsecase := usecase.NewLayerUsecase(layerRepo)
+	limits, err := usecase.UploadLimitsFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	uploadUsecase := usecase.NewUploadUsecase(fileStorage, blobRepo, limits)
+	resumableUsecase := usecase.NewResumableUploadUsecase(uploadSessionRepo, fileStorage, uploadUsecase, limits)
+	tagUsecase := usecase.NewTagUsecase(tagRepo)
+	mediaUsecase := usecase.NewMediaUsecase(comicRepo, uploadUsecase, mediaConfig())
+	checkProtectedStorage(fileStorage, comicRepo, assetBaseURL)
+	comicUsecase := usecase.NewComicUsecase(comicRepo, userRepo, tagUsecase, uploadUsecase, mediaUsecase)
+	importUsecase := usecase.NewImportUsecase(comicRepo, uploadUsecase)
+	exportUsecase := usecase.NewExportUsecase(comicRepo, uploadUsecase)
+	searchUsecase := usecase.NewSearchUsecase(searchRepo, userRepo)
+	layerUsecase := usecase.NewLayerUsecase(layerRepo, comicRepo)
 	adminUsecase := usecase.NewAdminUsecase(userRepo)
 
 	// Handlers
 	httpDelivery.NewAuthHandler(app, authUsecase)
 	httpDelivery.NewUserHandler(app, userUsecase)
-	httpDelivery.NewComicHandler(app, comicUsecase)
+	httpDelivery.NewComicHandler(app, comicUsecase, importUsecase, resumableUsecase)
+	httpDelivery.NewExportHandler(app, exportUsecase)
 	httpDelivery.NewLayerHandler(app, layerUsecase)
 	httpDelivery.NewAdminHandler(app, adminUsecase)
-	httpDelivery.NewUploadHandler(app)
+	httpDelivery.NewTagHandler(app, tagUsecase)
+	httpDelivery.NewSearchHandler(app, searchUsecase)
+	httpDelivery.NewUploadHandler(app, uploadUsecase)
+	httpDelivery.NewMediaHandler(app, mediaUsecase)
+	httpDelivery.NewResumableUploadHandler(app, resumableUsecase, limits.MaxBytes)
 
-	// Static files
-	app.Static("/uploads", "./uploads")
+	// Background jobs
+	publishInterval := time.Minute
+	if v := os.Getenv("PUBLISH_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Fatal("Invalid PUBLISH_INTERVAL: ", err)
+		}
+		publishInterval = d
+	}
+	go worker.RunPublisher(context.Background(), comicUsecase, publishInterval)
+	go worker.RunUploadJanitor(context.Background(), resumableUsecase, time.Hour)
+	if v := os.Getenv("STORAGE_GC_INTERVAL"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			log.Fatal("Invalid STORAGE_GC_INTERVAL: ", err)
+		}
+		grace := usecase.DefaultStorageGCGrace
+		if v := os.Getenv("STORAGE_GC_GRACE"); v != "" {
+			if grace, err = time.ParseDuration(v); err != nil {
+				log.Fatal("Invalid STORAGE_GC_GRACE: ", err)
+			}
+		}
+		dryRun := false
+		if v := os.Getenv("STORAGE_GC_DRY_RUN"); v != "" {
+			if dryRun, err = strconv.ParseBool(v); err != nil {
+				log.Fatal("Invalid STORAGE_GC_DRY_RUN: ", err)
+			}
+		}
+		gcUsecase := usecase.NewStorageGCUsecase(fileStorage, blobRepo)
+		go worker.RunStorageGC(context.Background(), gcUsecase, interval, grace, dryRun)
+	} else {
+		log.Println("STORAGE_GC_INTERVAL is not set: images released within 24h of upload stay stored until cmd/gc runs")
+	}
+
+	// Static files; other storage drivers serve their own URLs. Premium
+	// chapter pages are only served through signed /api/media URLs.
+	if local, ok := fileStorage.(*storage.LocalStorage); ok {
+		app.Static("/uploads", local.Dir(), fiber.Static{
+			Next: httpDelivery.SkipProtectedUploads(mediaUsecase, "/uploads"),
+		})
+	}
 
 	// Start Server
 	port := os.Getenv("PORT")
@@ -62,3 +146,71 @@ func main() {
 	}
 	log.Fatal(app.Listen(":" + port))
 }
+
+// checkProtectedStorage refuses to start if pages of monetized or
+// watermarked series could be fetched without a signed URL. Those pages stay
+// readable at their storage key on S3 and CDNs; signed URLs keep the key
+// secret, which only helps if the bucket doesn't list its keys to anyone.
+// Local storage refuses protected files at /uploads itself.
+func checkProtectedStorage(fileStorage domain.FileStorage, comicRepo domain.ComicRepository, assetBaseURL string) {
+	if _, ok := fileStorage.(*storage.LocalStorage); ok {
+		return
+	}
+	protected, err := comicRepo.HasProtectedSeries()
+	if err != nil {
+		log.Fatal("Failed to check for monetized or watermarked series: ", err)
+	}
+	if !protected {
+		return
+	}
+	for _, base := range []string{assetBaseURL, fileStorage.URL("")} {
+		listable, err := storage.PubliclyListable(base)
+		if err != nil {
+			log.Printf("Could not check whether %s lists its keys publicly: %v", base, err)
+			continue
+		}
+		if listable {
+			log.Fatalf("Storage at %s lists its keys publicly, so pages of monetized and watermarked series can be read without signed URLs. Turn off public listing on the bucket.", base)
+		}
+	}
+}
+
+// mediaConfig reads MEDIA_URL_SECRET and WATERMARK_SECRET (both falling
+// back to JWT_SECRET), MEDIA_BASE_URL, MEDIA_URL_TTL and
+// MEDIA_URL_BIND_USER.
+func mediaConfig() usecase.MediaConfig {
+	cfg := usecase.MediaConfig{
+		Secret:          []byte(os.Getenv("MEDIA_URL_SECRET")),
+		BaseURL:         os.Getenv("MEDIA_BASE_URL"),
+		TTL:             usecase.DefaultMediaURLTTL,
+		WatermarkSecret: []byte(os.Getenv("WATERMARK_SECRET")),
+	}
+	if len(cfg.Secret) == 0 {
+		cfg.Secret = []byte(os.Getenv("JWT_SECRET"))
+	}
+	if len(cfg.WatermarkSecret) == 0 {
+		cfg.WatermarkSecret = []byte(os.Getenv("JWT_SECRET"))
+	}
+	if cfg.BaseURL == "" {
+		port := os.Getenv("PORT")
+		if port == "" {
+			port = "8080"
+		}
+		cfg.BaseURL = "http://localhost:" + port
+	}
+	if v := os.Getenv("MEDIA_URL_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Fatalf("Invalid MEDIA_URL_TTL: %q", v)
+		}
+		cfg.TTL = d
+	}
+	if v := os.Getenv("MEDIA_URL_BIND_USER"); v != "" {
+		bind, err := strconv.ParseBool(v)
+		if err != nil {
+			log.Fatal("Invalid MEDIA_URL_BIND_USER: ", err)
+		}
+		cfg.BindUser = bind
+	}
+	return cfg
+}
@@ -0,0 +1,173 @@
+// Command test_layer_auth checks that only a series' owner and its
+// collaborators, who need not be creators, can change the text layers and
+// translations on its pages.
+// It needs a server running on localhost:8080.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const baseURL = "http://localhost:8080/api"
+
+var failed bool
+
+func main() {
+	ownerToken, _ := signup("creator")
+	otherToken, otherID := signup("creator")
+	readerToken, readerID := signup("user")
+
+	// 1. The owner builds a series down to a single page with one layer.
+	var series, season, chapter map[string]interface{}
+	mustDo(ownerToken, "POST", "/creator/series/", `{"title": {"en": "Layer Auth Test"}}`, http.StatusCreated, &series)
+	mustDo(ownerToken, "POST", "/creator/series/"+id(series)+"/seasons", `{"season_number": 1, "title": "Season 1"}`, http.StatusCreated, &season)
+	mustDo(ownerToken, "POST", "/creator/seasons/"+id(season)+"/chapters", `{"chapter_number": 1, "title": "Chapter 1"}`, http.StatusCreated, &chapter)
+	pageID := uploadPage(ownerToken, id(chapter))
+
+	var layer map[string]interface{}
+	layerBody := fmt.Sprintf(`{"chapter_image_id": "%s", "original_text": "Hello", "position_x": 10, "position_y": 10, "width": 20, "height": 10, "type": "bubble"}`, pageID)
+	mustDo(ownerToken, "POST", "/creator/layers/", layerBody, http.StatusCreated, &layer)
+	layerID := id(layer)
+
+	// 2. Another creator is refused everywhere.
+	checkOther := func(want int) {
+		check("add layer", otherToken, "POST", "/creator/layers/", layerBody, want, http.StatusCreated)
+		check("list layers", otherToken, "GET", "/creator/pages/"+pageID+"/layers/", "", want, http.StatusOK)
+		check("get layer", otherToken, "GET", "/creator/layers/"+layerID, "", want, http.StatusOK)
+		check("update layer", otherToken, "PATCH", "/creator/layers/"+layerID, `{"original_text": "Defaced"}`, want, http.StatusOK)
+		check("translate layer", otherToken, "POST", "/creator/layers/"+layerID+"/translate", `{"target_lang": "th"}`, want, http.StatusOK)
+	}
+	checkOther(http.StatusForbidden)
+	check("save layers", otherToken, "PUT", "/creator/pages/"+pageID+"/layers/", `{"layers": []}`, http.StatusForbidden, 0)
+	check("delete layer", otherToken, "DELETE", "/creator/layers/"+layerID, "", http.StatusForbidden, 0)
+	check("add collaborator as non-owner", otherToken, "POST", "/creator/series/"+id(series)+"/collaborators", fmt.Sprintf(`{"user_id": "%s"}`, otherID), http.StatusForbidden, 0)
+
+	// 3. Once added as a collaborator they can edit.
+	check("add collaborator", ownerToken, "POST", "/creator/series/"+id(series)+"/collaborators", fmt.Sprintf(`{"user_id": "%s"}`, otherID), http.StatusCreated, 0)
+	checkOther(0)
+
+	// 4. And after removal they can't again.
+	check("remove collaborator", ownerToken, "DELETE", "/creator/series/"+id(series)+"/collaborators/"+otherID, "", http.StatusNoContent, 0)
+	checkOther(http.StatusForbidden)
+
+	// 5. Collaborators don't need the creator role, e.g. to translate.
+	check("reader translates", readerToken, "POST", "/creator/layers/"+layerID+"/translate", `{"target_lang": "th"}`, http.StatusForbidden, 0)
+	check("add reader collaborator", ownerToken, "POST", "/creator/series/"+id(series)+"/collaborators", fmt.Sprintf(`{"user_id": "%s"}`, readerID), http.StatusCreated, 0)
+	check("reader collaborator translates", readerToken, "POST", "/creator/layers/"+layerID+"/translate", `{"target_lang": "th"}`, http.StatusOK, 0)
+	check("reader collaborator replaces page", readerToken, "PUT", "/creator/pages/"+pageID, "", http.StatusForbidden, 0)
+
+	// 6. The owner still can.
+	check("owner deletes layer", ownerToken, "DELETE", "/creator/layers/"+layerID, "", http.StatusNoContent, 0)
+
+	if failed {
+		os.WriteFile("test_result.txt", []byte("FAILURE: Layer authorization"), 0644)
+		log.Fatal("FAILURE: Layer authorization")
+	}
+	os.WriteFile("test_result.txt", []byte("SUCCESS: Layer authorization"), 0644)
+	fmt.Println("SUCCESS: Layer authorization")
+}
+
+// check expects want, or fallback when want is 0.
+func check(name, token, method, path, body string, want, fallback int) {
+	if want == 0 {
+		want = fallback
+	}
+	status, _ := do(token, method, path, body)
+	if status != want {
+		failed = true
+		fmt.Printf("FAIL %s: got %d, want %d\n", name, status, want)
+		return
+	}
+	fmt.Printf("ok   %s: %d\n", name, status)
+}
+
+func signup(role string) (token, userID string) {
+	username := "test_" + role + "_" + uuid.New().String()[:8]
+	email := username + "@example.com"
+	password := "password123"
+
+	var user map[string]interface{}
+	mustDo("", "POST", "/auth/signup", fmt.Sprintf(`{"username":"%s", "email":"%s", "password":"%s", "role":"%s"}`, username, email, password, role), http.StatusCreated, &user)
+
+	var login map[string]interface{}
+	mustDo("", "POST", "/auth/login", fmt.Sprintf(`{"identifier":"%s", "password":"%s"}`, email, password), http.StatusOK, &login)
+	return login["token"].(string), id(user)
+}
+
+func uploadPage(token, chapterID string) string {
+	img := image.NewRGBA(image.Rect(0, 0, 80, 120))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	img.Set(40, 60, color.Black)
+
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	part, _ := form.CreateFormFile("files", "page.png")
+	png.Encode(part, img)
+	form.Close()
+
+	req, _ := http.NewRequest("POST", baseURL+"/creator/chapters/"+chapterID+"/pages", &buf)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		log.Fatalf("Upload page failed: %d", resp.StatusCode)
+	}
+
+	var pages []map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&pages)
+	if len(pages) == 0 {
+		log.Fatal("Upload page returned no pages")
+	}
+	return id(pages[0])
+}
+
+func mustDo(token, method, path, body string, want int, out interface{}) {
+	status, data := do(token, method, path, body)
+	if status != want {
+		log.Fatalf("%s %s failed: %d %s", method, path, status, data)
+	}
+	if out != nil {
+		json.Unmarshal(data, out)
+	}
+}
+
+func do(token, method, path, body string) (int, []byte) {
+	req, _ := http.NewRequest(method, baseURL+path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, data
+}
+
+func id(v map[string]interface{}) string {
+	s, _ := v["id"].(string)
+	return s
+}
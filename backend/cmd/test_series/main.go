@@ -48,7 +48,8 @@ func main() {
 	createSeriesBody := fmt.Sprintf(`{
 		"title": {"en": "%s"},
 		"description": {"en": "%s"},
-		"cover_image_url": "http://example.com/cover.jpg"
+		"cover_image_url": "http://example.com/cover.jpg",
+		"status": "published"
 	}`, seriesTitle, seriesDesc)
 
 	client := &http.Client{}
@@ -73,8 +74,11 @@ func main() {
 	}
 	defer resp.Body.Close()
 
-	var seriesList []map[string]interface{}
-	json.NewDecoder(resp.Body).Decode(&seriesList)
+	var page struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	json.NewDecoder(resp.Body).Decode(&page)
+	seriesList := page.Items
 
 	if len(seriesList) == 0 {
 		log.Fatal("No series found")
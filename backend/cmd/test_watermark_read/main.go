@@ -0,0 +1,112 @@
+// Command test_watermark_read checks that chapters of a watermarked series
+// ask anonymous readers to sign in, while signed-in readers can read them.
+// It needs a server running on localhost:8080.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const baseURL = "http://localhost:8080/api"
+
+var failed bool
+
+func main() {
+	ownerToken := signup("creator")
+	readerToken := signup("user")
+
+	// 1. The owner publishes a watermarked series with one chapter.
+	var series, season, chapter map[string]interface{}
+	mustDo(ownerToken, "POST", "/creator/series/", `{"title": {"en": "Watermark Read Test"}, "status": "published", "visibility": "public", "watermark": "visible"}`, http.StatusCreated, &series)
+	mustDo(ownerToken, "POST", "/creator/series/"+id(series)+"/seasons", `{"season_number": 1, "title": "Season 1"}`, http.StatusCreated, &season)
+	mustDo(ownerToken, "POST", "/creator/seasons/"+id(season)+"/chapters", `{"chapter_number": 1, "title": "Chapter 1", "status": "published"}`, http.StatusCreated, &chapter)
+	chapterPath := "/chapters/" + id(chapter)
+
+	// 2. Anonymous readers are asked to sign in rather than told it's missing.
+	status, data := do("", "GET", chapterPath, "")
+	var body map[string]interface{}
+	json.Unmarshal(data, &body)
+	message, _ := body["error"].(string)
+	if status != http.StatusForbidden || !strings.Contains(message, "sign in") {
+		failed = true
+		fmt.Printf("FAIL anonymous read: got %d %q, want %d sign in\n", status, message, http.StatusForbidden)
+	} else {
+		fmt.Printf("ok   anonymous read: %d %q\n", status, message)
+	}
+
+	// 3. Signed-in readers and the owner can read it.
+	check("reader read", readerToken, "GET", chapterPath, http.StatusOK)
+	check("owner read", ownerToken, "GET", chapterPath, http.StatusOK)
+
+	// 4. Missing chapters are still reported as not found.
+	check("missing chapter", "", "GET", "/chapters/"+uuid.New().String(), http.StatusNotFound)
+
+	if failed {
+		os.WriteFile("test_result.txt", []byte("FAILURE: Watermarked chapter reads"), 0644)
+		log.Fatal("FAILURE: Watermarked chapter reads")
+	}
+	os.WriteFile("test_result.txt", []byte("SUCCESS: Watermarked chapter reads"), 0644)
+	fmt.Println("SUCCESS: Watermarked chapter reads")
+}
+
+func check(name, token, method, path string, want int) {
+	status, _ := do(token, method, path, "")
+	if status != want {
+		failed = true
+		fmt.Printf("FAIL %s: got %d, want %d\n", name, status, want)
+		return
+	}
+	fmt.Printf("ok   %s: %d\n", name, status)
+}
+
+func signup(role string) string {
+	username := "test_" + role + "_" + uuid.New().String()[:8]
+	email := username + "@example.com"
+	password := "password123"
+
+	mustDo("", "POST", "/auth/signup", fmt.Sprintf(`{"username":"%s", "email":"%s", "password":"%s", "role":"%s"}`, username, email, password, role), http.StatusCreated, nil)
+
+	var login map[string]interface{}
+	mustDo("", "POST", "/auth/login", fmt.Sprintf(`{"identifier":"%s", "password":"%s"}`, email, password), http.StatusOK, &login)
+	return login["token"].(string)
+}
+
+func mustDo(token, method, path, body string, want int, out interface{}) {
+	status, data := do(token, method, path, body)
+	if status != want {
+		log.Fatalf("%s %s failed: %d %s", method, path, status, data)
+	}
+	if out != nil {
+		json.Unmarshal(data, out)
+	}
+}
+
+func do(token, method, path, body string) (int, []byte) {
+	req, _ := http.NewRequest(method, baseURL+path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, data
+}
+
+func id(v map[string]interface{}) string {
+	s, _ := v["id"].(string)
+	return s
+}
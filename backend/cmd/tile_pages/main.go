@@ -0,0 +1,53 @@
+// Command tile_pages splits tall chapter pages stored before pages were
+// tiled, so webtoon strips already on the site reach mobile readers in
+// pieces too. It is safe to run repeatedly; pages with tiles are skipped.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	postgres "github.com/pur108/ebook-platform/backend/internal/repository/supabase"
+	"github.com/pur108/ebook-platform/backend/internal/storage"
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "list the pages that need tiles without tiling them")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	limits, err := usecase.UploadLimitsFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	db := postgres.NewDB()
+	uploadUsecase := usecase.NewUploadUsecase(storage.NewStorage(), postgres.NewBlobRepository(db), limits)
+	backfillUsecase := usecase.NewTileBackfillUsecase(postgres.NewComicRepository(db), uploadUsecase)
+
+	report, err := backfillUsecase.BackfillTiles(*dryRun)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, img := range report.Tiled {
+		fmt.Printf("%s\t%dx%d\t%d tiles\t%s\n", img.ID, img.Width, img.Height, len(img.Tiles), img.ImageURL)
+	}
+	for _, f := range report.Failed {
+		fmt.Fprintf(os.Stderr, "%s\tfailed: %v\n", f.ImageID, f.Err)
+	}
+	if *dryRun {
+		fmt.Printf("Dry run: %d pages need tiles. Re-run without -dry-run to tile them.\n", len(report.Tiled))
+		return
+	}
+	fmt.Printf("Tiled %d pages, %d failed\n", len(report.Tiled), len(report.Failed))
+	if len(report.Failed) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,84 @@
+// Command watermark_decode reads the reader watermark from leaked page
+// images and reports whose account each was served to. The image must be
+// at the size it was served at; resized or cropped copies can't be read.
+//
+//	go run ./cmd/watermark_decode leaked-page.jpg ...
+//
+// WATERMARK_SECRET (or JWT_SECRET) must match the server's. With -lookup
+// the user's name and email are fetched from the database.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"log"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	postgres "github.com/pur108/ebook-platform/backend/internal/repository/supabase"
+	"github.com/pur108/ebook-platform/backend/internal/watermark"
+	_ "golang.org/x/image/webp"
+)
+
+func main() {
+	lookup := flag.Bool("lookup", false, "look up each user in the database")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("usage: watermark_decode [-lookup] image...")
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+	secret := os.Getenv("WATERMARK_SECRET")
+	if secret == "" {
+		secret = os.Getenv("JWT_SECRET")
+	}
+
+	var userRepo domain.UserRepository
+	if *lookup {
+		userRepo = postgres.NewUserRepository(postgres.NewDB())
+	}
+
+	failed := false
+	for _, path := range flag.Args() {
+		userID, err := decodeFile(path, []byte(secret))
+		if err != nil {
+			fmt.Printf("%s: %v\n", path, err)
+			failed = true
+			continue
+		}
+		if userRepo == nil {
+			fmt.Printf("%s: %s\n", path, userID)
+			continue
+		}
+		user, err := userRepo.FindByID(userID)
+		if err != nil {
+			fmt.Printf("%s: %s (user not found: %v)\n", path, userID, err)
+			continue
+		}
+		fmt.Printf("%s: %s %s <%s>\n", path, userID, user.Username, user.Email)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func decodeFile(path string, secret []byte) (uuid.UUID, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return watermark.Decode(img, secret)
+}
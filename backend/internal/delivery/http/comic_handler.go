@@ -1,6 +1,10 @@
 package http
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/pur108/ebook-platform/backend/internal/domain"
@@ -9,20 +13,50 @@ import (
 )
 
 type ComicHandler struct {
-	comicUsecase usecase.ComicUsecase
+	comicUsecase     usecase.ComicUsecase
+	importUsecase    usecase.ImportUsecase
+	resumableUsecase usecase.ResumableUploadUsecase
 }
 
-func NewComicHandler(app *fiber.App, comicUsecase usecase.ComicUsecase) {
-	handler := &ComicHandler{comicUsecase}
+func NewComicHandler(app *fiber.App, comicUsecase usecase.ComicUsecase, importUsecase usecase.ImportUsecase, resumableUsecase usecase.ResumableUploadUsecase) {
+	handler := &ComicHandler{comicUsecase, importUsecase, resumableUsecase}
 
 	// Public routes
-	app.Get("/api/series", handler.ListSeries)
-	app.Get("/api/series/:id", handler.GetSeries)
-	app.Get("/api/chapters/:id", handler.GetChapter)
+	app.Get("/api/series", middleware.OptionalAuth(), handler.ListSeries)
+	app.Get("/api/series/:id", middleware.OptionalAuth(), handler.GetSeries)
+	app.Get("/api/chapters/:id", middleware.OptionalAuth(), handler.GetChapter)
 
 	// Creator routes
 	creatorGroup := app.Group("/api/creator/series", middleware.Protected(), middleware.RoleRequired(domain.RoleCreator, domain.RoleAdmin))
+	creatorGroup.Get("/", handler.ListCreatorSeries)
 	creatorGroup.Post("/", handler.CreateSeries)
+	creatorGroup.Patch("/:id", handler.UpdateSeries)
+	creatorGroup.Delete("/:id", handler.DeleteSeries)
+	creatorGroup.Post("/:id/archive", handler.ArchiveSeries)
+	creatorGroup.Get("/:id/seasons", handler.ListSeasons)
+	creatorGroup.Post("/:id/seasons", handler.CreateSeason)
+	creatorGroup.Get("/:id/collaborators", handler.ListCollaborators)
+	creatorGroup.Post("/:id/collaborators", handler.AddCollaborator)
+	creatorGroup.Delete("/:id/collaborators/:userId", handler.RemoveCollaborator)
+
+	seasonGroup := app.Group("/api/creator/seasons", middleware.Protected(), middleware.RoleRequired(domain.RoleCreator, domain.RoleAdmin))
+	seasonGroup.Patch("/:id", handler.UpdateSeason)
+	seasonGroup.Delete("/:id", handler.DeleteSeason)
+	seasonGroup.Post("/:id/chapters", handler.CreateChapter)
+	seasonGroup.Post("/:id/chapters/import", handler.ImportChapters)
+
+	chapterGroup := app.Group("/api/creator/chapters", middleware.Protected(), middleware.RoleRequired(domain.RoleCreator, domain.RoleAdmin))
+	chapterGroup.Patch("/:id", handler.UpdateChapter)
+	chapterGroup.Delete("/:id", handler.DeleteChapter)
+	chapterGroup.Post("/:id/pages", handler.AddChapterPages)
+	chapterGroup.Put("/:id/pages/order", handler.ReorderChapterPages)
+
+	// Middleware goes on each route rather than the group: group middleware
+	// would also cover the layer routes under /api/creator/pages/:id/layers,
+	// which collaborators without the creator role may use.
+	pageGroup := app.Group("/api/creator/pages")
+	pageGroup.Put("/:id", middleware.Protected(), middleware.RoleRequired(domain.RoleCreator, domain.RoleAdmin), handler.ReplaceChapterPage)
+	pageGroup.Delete("/:id", middleware.Protected(), middleware.RoleRequired(domain.RoleCreator, domain.RoleAdmin), handler.DeleteChapterPage)
 }
 
 func (h *ComicHandler) CreateSeries(c *fiber.Ctx) error {
@@ -48,12 +82,148 @@ func (h *ComicHandler) CreateSeries(c *fiber.Ctx) error {
 
 	series, err := h.comicUsecase.CreateSeries(req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return respondError(c, err)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(series)
 }
 
+func (h *ComicHandler) ListCreatorSeries(c *fiber.Ctx) error {
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	series, err := h.comicUsecase.ListCreatorSeries(req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch series"})
+	}
+
+	return c.JSON(series)
+}
+
+func (h *ComicHandler) UpdateSeries(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid series ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	var input usecase.UpdateSeriesInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	series, err := h.comicUsecase.UpdateSeries(req, seriesID, input)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(series)
+}
+
+func (h *ComicHandler) ArchiveSeries(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid series ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	series, err := h.comicUsecase.ArchiveSeries(req, seriesID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(series)
+}
+
+func (h *ComicHandler) DeleteSeries(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid series ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	if err := h.comicUsecase.DeleteSeries(req, seriesID); err != nil {
+		return respondError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *ComicHandler) ListCollaborators(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid series ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	collaborators, err := h.comicUsecase.ListCollaborators(req, seriesID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(collaborators)
+}
+
+func (h *ComicHandler) AddCollaborator(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid series ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	var input struct {
+		UserID uuid.UUID `json:"user_id"`
+	}
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	collaborator, err := h.comicUsecase.AddCollaborator(req, seriesID, input.UserID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(collaborator)
+}
+
+func (h *ComicHandler) RemoveCollaborator(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid series ID"})
+	}
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	if err := h.comicUsecase.RemoveCollaborator(req, seriesID, userID); err != nil {
+		return respondError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
 func (h *ComicHandler) GetSeries(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
@@ -61,9 +231,9 @@ func (h *ComicHandler) GetSeries(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid series ID"})
 	}
 
-	series, err := h.comicUsecase.GetSeries(id)
+	series, err := h.comicUsecase.GetSeries(viewerFromCtx(c), id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Series not found"})
+		return respondError(c, err)
 	}
 
 	return c.JSON(series)
@@ -76,19 +246,386 @@ func (h *ComicHandler) GetChapter(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid chapter ID"})
 	}
 
-	chapter, err := h.comicUsecase.GetChapter(id)
+	chapter, err := h.comicUsecase.GetChapter(viewerFromCtx(c), id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Chapter not found"})
+		return respondError(c, err)
 	}
 
 	return c.JSON(chapter)
 }
 
+// ListSeries serves the public catalogue. Supported query parameters:
+// genre, tag (slug), status (comma-separated), creator_id, nsfw (true/false),
+// available_in (language code), sort (newest, updated, popular, title),
+// locale (title language for sort=title), cursor and limit.
 func (h *ComicHandler) ListSeries(c *fiber.Ctx) error {
-	series, err := h.comicUsecase.ListSeries()
+	input := usecase.ListSeriesInput{
+		Genre:       c.Query("genre"),
+		TagSlug:     c.Query("tag"),
+		AvailableIn: c.Query("available_in"),
+		Sort:        domain.SeriesSort(c.Query("sort")),
+		Locale:      c.Query("locale"),
+		Cursor:      c.Query("cursor"),
+		Limit:       c.QueryInt("limit", domain.DefaultSeriesPageSize),
+	}
+	if status := c.Query("status"); status != "" {
+		for _, s := range strings.Split(status, ",") {
+			input.Statuses = append(input.Statuses, domain.SeriesStatus(strings.TrimSpace(s)))
+		}
+	}
+	if creator := c.Query("creator_id"); creator != "" {
+		creatorID, err := uuid.Parse(creator)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid creator ID"})
+		}
+		input.CreatorID = &creatorID
+	}
+	if nsfw := c.Query("nsfw"); nsfw != "" {
+		v, err := strconv.ParseBool(nsfw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "nsfw must be true or false"})
+		}
+		input.NSFW = &v
+	}
+
+	page, err := h.comicUsecase.ListSeries(viewerFromCtx(c), input)
+	if errors.Is(err, usecase.ErrInvalidInput) {
+		return respondError(c, err)
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch series"})
 	}
 
-	return c.JSON(series)
+	return c.JSON(page)
+}
+
+func (h *ComicHandler) ListSeasons(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid series ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	seasons, err := h.comicUsecase.ListSeasons(req, seriesID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(seasons)
+}
+
+func (h *ComicHandler) CreateSeason(c *fiber.Ctx) error {
+	seriesID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid series ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	var input usecase.SeasonInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	season, err := h.comicUsecase.CreateSeason(req, seriesID, input)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(season)
+}
+
+func (h *ComicHandler) UpdateSeason(c *fiber.Ctx) error {
+	seasonID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid season ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	var input usecase.UpdateSeasonInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	season, err := h.comicUsecase.UpdateSeason(req, seasonID, input)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(season)
+}
+
+func (h *ComicHandler) DeleteSeason(c *fiber.Ctx) error {
+	seasonID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid season ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	if err := h.comicUsecase.DeleteSeason(req, seasonID); err != nil {
+		return respondError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *ComicHandler) CreateChapter(c *fiber.Ctx) error {
+	seasonID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid season ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	var input usecase.ChapterInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	chapter, err := h.comicUsecase.CreateChapter(req, seasonID, input)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(chapter)
+}
+
+// ImportChapters creates one or more chapters from a CBZ/ZIP uploaded as
+// "file". Optional form fields chapter_number, title and status apply when
+// the archive doesn't say otherwise.
+func (h *ComicHandler) ImportChapters(c *fiber.Ctx) error {
+	seasonID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid season ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "No file uploaded"})
+	}
+	archive, err := fh.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Failed to read uploaded file"})
+	}
+	defer archive.Close()
+
+	defaults := usecase.ChapterInput{
+		Title:  c.FormValue("title"),
+		Status: domain.ChapterStatus(c.FormValue("status")),
+	}
+	if n := c.FormValue("chapter_number"); n != "" {
+		defaults.ChapterNumber, err = strconv.Atoi(n)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid chapter number"})
+		}
+	}
+
+	chapters, err := h.importUsecase.ImportChapters(req, seasonID, archive, fh.Size, defaults)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(chapters)
+}
+
+func (h *ComicHandler) UpdateChapter(c *fiber.Ctx) error {
+	chapterID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid chapter ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	var input usecase.UpdateChapterInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	chapter, err := h.comicUsecase.UpdateChapter(req, chapterID, input)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(chapter)
+}
+
+func (h *ComicHandler) DeleteChapter(c *fiber.Ctx) error {
+	chapterID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid chapter ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	if err := h.comicUsecase.DeleteChapter(req, chapterID); err != nil {
+		return respondError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AddChapterPages appends pages sent as multipart files ("files" or
+// "file"), or, with a JSON body of {"upload_ids": [...]}, pages that were
+// sent through the resumable upload endpoint.
+func (h *ComicHandler) AddChapterPages(c *fiber.Ctx) error {
+	chapterID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid chapter ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
+		return h.addChapterPagesFromUploads(c, req, chapterID)
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "No file uploaded"})
+	}
+	headers := append(form.File["files"], form.File["file"]...)
+	if len(headers) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "No file uploaded"})
+	}
+
+	files := make([]usecase.FileUpload, 0, len(headers))
+	for _, fh := range headers {
+		src, file, err := openFormFile(fh)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Failed to read uploaded file"})
+		}
+		defer src.Close()
+		files = append(files, file)
+	}
+
+	images, err := h.comicUsecase.AddChapterPages(req, chapterID, files)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(images)
+}
+
+func (h *ComicHandler) addChapterPagesFromUploads(c *fiber.Ctx, req usecase.Requester, chapterID uuid.UUID) error {
+	var body struct {
+		UploadIDs []uuid.UUID `json:"upload_ids"`
+	}
+	if err := c.BodyParser(&body); err != nil || len(body.UploadIDs) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	files := make([]usecase.FileUpload, 0, len(body.UploadIDs))
+	for _, id := range body.UploadIDs {
+		file, closer, err := h.resumableUsecase.OpenUpload(req, id)
+		if err != nil {
+			return respondError(c, err)
+		}
+		defer closer.Close()
+		files = append(files, file)
+	}
+
+	images, err := h.comicUsecase.AddChapterPages(req, chapterID, files)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	// The pages are stored; the upload sessions have served their purpose.
+	for _, id := range body.UploadIDs {
+		h.resumableUsecase.DeleteUpload(req, id)
+	}
+	return c.Status(fiber.StatusCreated).JSON(images)
+}
+
+func (h *ComicHandler) ReorderChapterPages(c *fiber.Ctx) error {
+	chapterID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid chapter ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	type Request struct {
+		ImageIDs []uuid.UUID `json:"image_ids"`
+	}
+	var body Request
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	images, err := h.comicUsecase.ReorderChapterPages(req, chapterID, body.ImageIDs)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(images)
+}
+
+func (h *ComicHandler) ReplaceChapterPage(c *fiber.Ctx) error {
+	imageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid page ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "No file uploaded"})
+	}
+	src, file, err := openFormFile(fh)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Failed to read uploaded file"})
+	}
+	defer src.Close()
+
+	image, err := h.comicUsecase.ReplaceChapterPage(req, imageID, file)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(image)
+}
+
+func (h *ComicHandler) DeleteChapterPage(c *fiber.Ctx) error {
+	imageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid page ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	if err := h.comicUsecase.DeleteChapterPage(req, imageID); err != nil {
+		return respondError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
 }
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"github.com/pur108/ebook-platform/backend/internal/middleware"
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+type ExportHandler struct {
+	exportUsecase usecase.ExportUsecase
+}
+
+func NewExportHandler(app *fiber.App, exportUsecase usecase.ExportUsecase) {
+	handler := &ExportHandler{exportUsecase}
+
+	creator := []fiber.Handler{middleware.Protected(), middleware.RoleRequired(domain.RoleCreator, domain.RoleAdmin)}
+	app.Get("/api/creator/chapters/:id/export", append(creator, handler.export(usecase.ExportScopeChapter))...)
+	app.Get("/api/creator/seasons/:id/export", append(creator, handler.export(usecase.ExportScopeSeason))...)
+	app.Get("/api/creator/series/:id/export", append(creator, handler.export(usecase.ExportScopeSeries))...)
+}
+
+// export returns a handler that packages the chapter, season or series in
+// the URL. Query parameters: format (cbz or epub, default cbz) and lang (the
+// translation language to overlay in EPUB exports).
+func (h *ExportHandler) export(scope usecase.ExportScope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params("id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid %s ID", scope)})
+		}
+		req, err := requesterFromCtx(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+		}
+
+		plan, err := h.exportUsecase.PrepareExport(req, usecase.ExportInput{
+			Scope:    scope,
+			ID:       id,
+			Format:   usecase.ExportFormat(c.Query("format", string(usecase.ExportCBZ))),
+			Language: c.Query("lang"),
+		})
+		if err != nil {
+			return respondError(c, err)
+		}
+
+		c.Set(fiber.HeaderContentType, plan.ContentType)
+		c.Set(fiber.HeaderContentDisposition, attachmentDisposition(plan.Filename))
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			// Headers are already sent, so a failure here can only be
+			// logged; the client sees a truncated archive.
+			if err := h.exportUsecase.WriteExport(plan, w); err != nil {
+				log.Println("Export failed: ", err)
+			}
+			w.Flush()
+		})
+		return nil
+	}
+}
+
+// attachmentDisposition builds a Content-Disposition header for filename,
+// which may be Thai: an ASCII filename for old clients plus the exact name
+// as an RFC 5987 filename* parameter, which clients prefer when they
+// understand it.
+func attachmentDisposition(filename string) string {
+	fallback := strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, filename)
+
+	var encoded strings.Builder
+	for _, b := range []byte(filename) {
+		if isAttrChar(b) {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, fallback, encoded.String())
+}
+
+// isAttrChar reports whether b may appear unescaped in an RFC 5987 value.
+func isAttrChar(b byte) bool {
+	switch {
+	case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$&+-.^_`|~", b) >= 0
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+	"gorm.io/gorm"
+)
+
+// requesterFromCtx builds a usecase.Requester from the claims stored by
+// middleware.Protected.
+func requesterFromCtx(c *fiber.Ctx) (usecase.Requester, error) {
+	userIDStr, _ := c.Locals("user_id").(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return usecase.Requester{}, err
+	}
+	role, _ := c.Locals("role").(string)
+	return usecase.Requester{UserID: userID, Role: domain.UserRole(role)}, nil
+}
+
+// viewerFromCtx is requesterFromCtx for routes behind middleware.OptionalAuth;
+// anonymous visitors get the zero Requester.
+func viewerFromCtx(c *fiber.Ctx) usecase.Requester {
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return usecase.Requester{}
+	}
+	return req
+}
+
+// respondError maps usecase errors to HTTP status codes.
+func respondError(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	var uploadErr *usecase.UploadError
+	switch {
+	case errors.As(err, &uploadErr):
+		status = fiber.StatusBadRequest
+		if uploadErr.Code == usecase.UploadCodeTooLarge {
+			status = fiber.StatusRequestEntityTooLarge
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  uploadErr.Code,
+		})
+	case errors.Is(err, usecase.ErrAgeGateRequired):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+			"code":  "age_gate_required",
+		})
+	case errors.Is(err, usecase.ErrNotFound), errors.Is(err, gorm.ErrRecordNotFound):
+		status = fiber.StatusNotFound
+	case errors.Is(err, usecase.ErrForbidden):
+		status = fiber.StatusForbidden
+	case errors.Is(err, usecase.ErrConflict):
+		status = fiber.StatusConflict
+	case errors.Is(err, usecase.ErrInvalidInput):
+		status = fiber.StatusBadRequest
+	}
+	return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+}
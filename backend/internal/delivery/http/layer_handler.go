@@ -15,10 +15,19 @@ type LayerHandler struct {
 func NewLayerHandler(app *fiber.App, layerUsecase usecase.LayerUsecase) {
 	handler := &LayerHandler{layerUsecase}
 
-	// Creator routes
-	creatorGroup := app.Group("/api/creator/layers", middleware.Protected(), middleware.RoleRequired(domain.RoleCreator, domain.RoleAdmin))
+	// Any signed-in user may call these; the usecase lets through only the
+	// series' owner, admins and collaborators, who may be readers
+	// translating someone else's series.
+	creatorGroup := app.Group("/api/creator/layers", middleware.Protected())
 	creatorGroup.Post("/", handler.AddLayer)
+	creatorGroup.Get("/:id", handler.GetLayer)
+	creatorGroup.Patch("/:id", handler.UpdateLayer)
+	creatorGroup.Delete("/:id", handler.DeleteLayer)
 	creatorGroup.Post("/:id/translate", handler.TranslateLayer)
+
+	pageGroup := app.Group("/api/creator/pages/:id/layers", middleware.Protected())
+	pageGroup.Get("/", handler.ListLayers)
+	pageGroup.Put("/", handler.SaveLayers)
 }
 
 func (h *LayerHandler) AddLayer(c *fiber.Ctx) error {
@@ -32,19 +41,125 @@ func (h *LayerHandler) AddLayer(c *fiber.Ctx) error {
 		Type           domain.TextLayerType `json:"type"`
 	}
 
-	var req Request
-	if err := c.BodyParser(&req); err != nil {
+	var input Request
+	if err := c.BodyParser(&input); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
 	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
 
-	layer, err := h.layerUsecase.AddLayer(req.ChapterImageID, req.OriginalText, req.PositionX, req.PositionY, req.Width, req.Height, req.Type)
+	layer, err := h.layerUsecase.AddLayer(req, input.ChapterImageID, input.OriginalText, input.PositionX, input.PositionY, input.Width, input.Height, input.Type)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return respondError(c, err)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(layer)
 }
 
+func (h *LayerHandler) ListLayers(c *fiber.Ctx) error {
+	imageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid page ID"})
+	}
+
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	layers, err := h.layerUsecase.ListLayers(req, imageID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(layers)
+}
+
+func (h *LayerHandler) GetLayer(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid layer ID"})
+	}
+
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	layer, err := h.layerUsecase.GetLayer(req, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(layer)
+}
+
+func (h *LayerHandler) UpdateLayer(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid layer ID"})
+	}
+
+	var input usecase.UpdateLayerInput
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	layer, err := h.layerUsecase.UpdateLayer(req, id, input)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(layer)
+}
+
+func (h *LayerHandler) DeleteLayer(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid layer ID"})
+	}
+
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	if err := h.layerUsecase.DeleteLayer(req, id); err != nil {
+		return respondError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// SaveLayers replaces all layers on a page with the request's, as the layer
+// editor saves: {"layers": [...]}. Layers sent with their ID are updated,
+// those without are created, and any not sent are deleted.
+func (h *LayerHandler) SaveLayers(c *fiber.Ctx) error {
+	imageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid page ID"})
+	}
+
+	var input struct {
+		Layers []usecase.LayerInput `json:"layers"`
+	}
+	if err := c.BodyParser(&input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	layers, err := h.layerUsecase.SaveLayers(req, imageID, input.Layers)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(layers)
+}
+
 func (h *LayerHandler) TranslateLayer(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
@@ -55,14 +170,18 @@ func (h *LayerHandler) TranslateLayer(c *fiber.Ctx) error {
 	type Request struct {
 		TargetLang string `json:"target_lang"`
 	}
-	var req Request
-	if err := c.BodyParser(&req); err != nil {
+	var input Request
+	if err := c.BodyParser(&input); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
 	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
 
-	translation, err := h.layerUsecase.TranslateLayer(id, req.TargetLang)
+	translation, err := h.layerUsecase.TranslateLayer(req, id, input.TargetLang)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return respondError(c, err)
 	}
 
 	return c.JSON(translation)
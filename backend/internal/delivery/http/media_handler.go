@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pur108/ebook-platform/backend/internal/middleware"
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+// MediaHandler streams files behind signed URLs, which is how pages of
+// monetized and watermarked series reach readers. The path holds an
+// encrypted token rather than the storage key.
+type MediaHandler struct {
+	mediaUsecase usecase.MediaUsecase
+}
+
+func NewMediaHandler(app *fiber.App, mediaUsecase usecase.MediaUsecase) {
+	handler := &MediaHandler{mediaUsecase}
+
+	app.Get("/api/media/:token", middleware.OptionalAuth(), handler.GetMedia)
+}
+
+func (h *MediaHandler) GetMedia(c *fiber.Ctx) error {
+	signed := usecase.SignedMedia{
+		Expires:   c.Query("expires"),
+		UserID:    c.Query("user"),
+		Watermark: c.Query("watermark"),
+		Signature: c.Query("signature"),
+	}
+
+	file, err := h.mediaUsecase.OpenSigned(viewerFromCtx(c), c.Params("token"), signed)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	if file.ContentType != "" {
+		c.Set(fiber.HeaderContentType, file.ContentType)
+	}
+	// Browsers may keep the page until the link expires, but shared caches
+	// must not serve it to anyone else.
+	maxAge := int(time.Until(file.Expires).Seconds())
+	c.Set(fiber.HeaderCacheControl, "private, max-age="+strconv.Itoa(maxAge))
+	return c.SendStream(file.Body)
+}
+
+// SkipProtectedUploads is a fiber.Static Next function that refuses to serve
+// files only reachable through signed URLs. Lookup failures refuse too.
+func SkipProtectedUploads(mediaUsecase usecase.MediaUsecase, prefix string) func(*fiber.Ctx) bool {
+	prefix = strings.TrimRight(prefix, "/") + "/"
+	return func(c *fiber.Ctx) bool {
+		key, err := url.PathUnescape(strings.TrimPrefix(c.Path(), prefix))
+		if err != nil {
+			return true
+		}
+		protected, err := mediaUsecase.IsProtected(key)
+		return err != nil || protected
+	}
+}
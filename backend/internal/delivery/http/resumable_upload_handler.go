@@ -0,0 +1,214 @@
+package http
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"github.com/pur108/ebook-platform/backend/internal/middleware"
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+const (
+	tusVersion = "1.0.0"
+	// statusChecksumMismatch is the tus checksum extension's status code.
+	statusChecksumMismatch = 460
+)
+
+// tusHeaders are the response headers browsers need to be allowed to read.
+var tusHeaders = []string{"Location", "Upload-Offset", "Upload-Length", "Upload-Expires", "Tus-Resumable", "Tus-Version", "Tus-Extension", "Tus-Max-Size", "Tus-Checksum-Algorithm"}
+
+// ResumableUploadHandler speaks the tus 1.0 protocol (core plus the
+// creation, checksum, expiration and termination extensions), so stock
+// clients such as tus-js-client or Uppy can upload large images in chunks
+// and resume after a dropped connection. Once every byte has arrived,
+// POST /:id/complete runs the file through the same pipeline as /api/upload.
+type ResumableUploadHandler struct {
+	resumableUsecase usecase.ResumableUploadUsecase
+	maxSize          int64
+}
+
+func NewResumableUploadHandler(app *fiber.App, resumableUsecase usecase.ResumableUploadUsecase, maxSize int64) {
+	handler := &ResumableUploadHandler{resumableUsecase, maxSize}
+
+	app.Options("/api/uploads/resumable", handler.Options)
+	uploads := app.Group("/api/uploads/resumable", handler.checkVersion, middleware.Protected())
+	uploads.Post("/", handler.Create)
+	uploads.Head("/:id", handler.Head)
+	uploads.Patch("/:id", handler.Patch)
+	uploads.Delete("/:id", handler.Delete)
+	uploads.Post("/:id/complete", handler.Complete)
+}
+
+// TusExposedHeaders lists headers to pass to the CORS middleware's
+// ExposeHeaders.
+func TusExposedHeaders() string {
+	return strings.Join(tusHeaders, ",")
+}
+
+// checkVersion rejects tus clients speaking another protocol version. Plain
+// HTTP clients that send no Tus-Resumable header are let through.
+func (h *ResumableUploadHandler) checkVersion(c *fiber.Ctx) error {
+	c.Set("Tus-Resumable", tusVersion)
+	if v := c.Get("Tus-Resumable"); v != "" && v != tusVersion {
+		c.Set("Tus-Version", tusVersion)
+		return c.SendStatus(fiber.StatusPreconditionFailed)
+	}
+	return c.Next()
+}
+
+func (h *ResumableUploadHandler) Options(c *fiber.Ctx) error {
+	c.Set("Tus-Resumable", tusVersion)
+	c.Set("Tus-Version", tusVersion)
+	c.Set("Tus-Extension", "creation,checksum,expiration,termination")
+	c.Set("Tus-Max-Size", strconv.FormatInt(h.maxSize, 10))
+	c.Set("Tus-Checksum-Algorithm", "sha256")
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Create starts an upload. The length comes from the Upload-Length header
+// and the filename from Upload-Metadata ("filename" or "name" key).
+func (h *ResumableUploadHandler) Create(c *fiber.Ctx) error {
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	size, err := strconv.ParseInt(c.Get("Upload-Length"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Upload-Length header is required"})
+	}
+	metadata := parseUploadMetadata(c.Get("Upload-Metadata"))
+	filename := metadata["filename"]
+	if filename == "" {
+		filename = metadata["name"]
+	}
+
+	session, err := h.resumableUsecase.CreateUpload(req, filename, size)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	c.Location(fmt.Sprintf("/api/uploads/resumable/%s", session.ID))
+	c.Set("Upload-Expires", session.ExpiresAt.UTC().Format(time.RFC1123))
+	return c.Status(fiber.StatusCreated).JSON(session)
+}
+
+func (h *ResumableUploadHandler) Head(c *fiber.Ctx) error {
+	session, status := h.session(c)
+	if session == nil {
+		return c.SendStatus(status)
+	}
+	c.Set("Cache-Control", "no-store")
+	c.Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Set("Upload-Length", strconv.FormatInt(session.Size, 10))
+	c.Set("Upload-Expires", session.ExpiresAt.UTC().Format(time.RFC1123))
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// session loads the upload for HEAD, whose responses can't carry a body.
+func (h *ResumableUploadHandler) session(c *fiber.Ctx) (*domain.UploadSession, int) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, fiber.StatusNotFound
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return nil, fiber.StatusBadRequest
+	}
+	session, err := h.resumableUsecase.GetUpload(req, id)
+	if errors.Is(err, usecase.ErrNotFound) {
+		return nil, fiber.StatusNotFound
+	}
+	if err != nil {
+		return nil, fiber.StatusInternalServerError
+	}
+	return session, fiber.StatusOK
+}
+
+// Patch appends the request body at Upload-Offset, verifying it against
+// Upload-Checksum when one is sent.
+func (h *ResumableUploadHandler) Patch(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Invalid upload ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	if c.Get(fiber.HeaderContentType) != "application/offset+octet-stream" {
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{"error": "Content-Type must be application/offset+octet-stream"})
+	}
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Upload-Offset header is required"})
+	}
+
+	session, err := h.resumableUsecase.WriteChunk(req, id, offset, c.Body(), c.Get("Upload-Checksum"))
+	if errors.Is(err, usecase.ErrChecksumMismatch) {
+		return c.Status(statusChecksumMismatch).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	c.Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Set("Upload-Expires", session.ExpiresAt.UTC().Format(time.RFC1123))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *ResumableUploadHandler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Invalid upload ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	if err := h.resumableUsecase.DeleteUpload(req, id); err != nil {
+		return respondError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Complete processes a fully received upload and responds like /api/upload.
+func (h *ResumableUploadHandler) Complete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Invalid upload ID"})
+	}
+	req, err := requesterFromCtx(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	stored, err := h.resumableUsecase.CompleteUpload(req, id)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(stored)
+}
+
+// parseUploadMetadata decodes a tus Upload-Metadata header: comma-separated
+// "key base64value" pairs. Malformed pairs are skipped.
+func parseUploadMetadata(header string) map[string]string {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			continue
+		}
+		metadata[key] = string(decoded)
+	}
+	return metadata
+}
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pur108/ebook-platform/backend/internal/middleware"
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+type SearchHandler struct {
+	searchUsecase usecase.SearchUsecase
+}
+
+func NewSearchHandler(app *fiber.App, searchUsecase usecase.SearchUsecase) {
+	handler := &SearchHandler{searchUsecase}
+
+	app.Get("/api/search", middleware.OptionalAuth(), handler.Search)
+}
+
+// Search handles GET /api/search?q=...&dialogue=true&limit=20.
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	input := usecase.SearchInput{
+		Query:           c.Query("q"),
+		IncludeDialogue: c.QueryBool("dialogue", false),
+		Limit:           c.QueryInt("limit", 0),
+	}
+
+	result, err := h.searchUsecase.Search(viewerFromCtx(c), input)
+	if errors.Is(err, usecase.ErrInvalidInput) {
+		return respondError(c, err)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Search failed"})
+	}
+
+	return c.JSON(result)
+}
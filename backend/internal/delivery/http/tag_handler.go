@@ -0,0 +1,78 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"github.com/pur108/ebook-platform/backend/internal/middleware"
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+type TagHandler struct {
+	tagUsecase usecase.TagUsecase
+}
+
+func NewTagHandler(app *fiber.App, tagUsecase usecase.TagUsecase) {
+	handler := &TagHandler{tagUsecase}
+
+	// Public routes
+	app.Get("/api/tags", handler.ListTags)
+
+	// Admin routes
+	adminGroup := app.Group("/api/admin/tags", middleware.Protected(), middleware.RoleRequired(domain.RoleAdmin))
+	adminGroup.Post("/:id/merge", handler.MergeTags)
+	adminGroup.Post("/:id/aliases", handler.AddAlias)
+}
+
+func (h *TagHandler) ListTags(c *fiber.Ctx) error {
+	tags, err := h.tagUsecase.ListTags()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to fetch tags"})
+	}
+
+	return c.JSON(tags)
+}
+
+// MergeTags folds the tag in the URL into target_id.
+func (h *TagHandler) MergeTags(c *fiber.Ctx) error {
+	sourceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid tag ID"})
+	}
+
+	type Request struct {
+		TargetID uuid.UUID `json:"target_id"`
+	}
+	var req Request
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	if err := h.tagUsecase.MergeTags(sourceID, req.TargetID); err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"message": "Tags merged successfully"})
+}
+
+func (h *TagHandler) AddAlias(c *fiber.Ctx) error {
+	tagID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid tag ID"})
+	}
+
+	type Request struct {
+		Slug string `json:"slug"`
+	}
+	var req Request
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	alias, err := h.tagUsecase.AddAlias(tagID, req.Slug)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(alias)
+}
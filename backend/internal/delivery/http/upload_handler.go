@@ -1,19 +1,19 @@
 package http
 
 import (
-	"fmt"
-	"path/filepath"
-	"strings"
+	"mime/multipart"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 	"github.com/pur108/ebook-platform/backend/internal/middleware"
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
 )
 
-type UploadHandler struct{}
+type UploadHandler struct {
+	uploadUsecase usecase.UploadUsecase
+}
 
-func NewUploadHandler(app *fiber.App) {
-	handler := &UploadHandler{}
+func NewUploadHandler(app *fiber.App, uploadUsecase usecase.UploadUsecase) {
+	handler := &UploadHandler{uploadUsecase}
 
 	app.Post("/api/upload", middleware.Protected(), handler.UploadFile)
 }
@@ -24,27 +24,31 @@ func (h *UploadHandler) UploadFile(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "No file uploaded"})
 	}
 
-	// Validate file extension
-	ext := strings.ToLower(filepath.Ext(file.Filename))
-	if ext != ".jpg" && ext != ".jpeg" && ext != ".png" && ext != ".webp" && ext != ".gif" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid file type. Only images are allowed."})
+	stored, err := saveFormImage(h.uploadUsecase, file)
+	if err != nil {
+		return respondError(c, err)
 	}
 
-	// Generate unique filename
-	filename := uuid.New().String() + ext
-	savePath := fmt.Sprintf("./uploads/%s", filename)
+	return c.JSON(stored)
+}
 
-	// Save file
-	if err := c.SaveFile(file, savePath); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save file"})
+// saveFormImage stores a multipart image through the upload pipeline.
+func saveFormImage(uploadUsecase usecase.UploadUsecase, fh *multipart.FileHeader) (*usecase.StoredImage, error) {
+	src, file, err := openFormFile(fh)
+	if err != nil {
+		return nil, err
 	}
+	defer src.Close()
 
-	// Return public URL
-	// Assuming the server is running on localhost:8080 and serving /uploads
-	// In production, this should be configured via env vars or return a relative path
-	url := fmt.Sprintf("http://localhost:8080/uploads/%s", filename)
+	return uploadUsecase.SaveImage(file.Filename, file.Reader)
+}
 
-	return c.JSON(fiber.Map{
-		"url": url,
-	})
+// openFormFile opens a multipart file for hand-off to a usecase. The caller
+// must close the returned multipart.File.
+func openFormFile(fh *multipart.FileHeader) (multipart.File, usecase.FileUpload, error) {
+	src, err := fh.Open()
+	if err != nil {
+		return nil, usecase.FileUpload{}, err
+	}
+	return src, usecase.FileUpload{Filename: fh.Filename, Reader: src}, nil
 }
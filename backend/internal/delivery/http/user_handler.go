@@ -1,6 +1,8 @@
 package http
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/pur108/ebook-platform/backend/internal/middleware"
@@ -16,6 +18,8 @@ func NewUserHandler(app *fiber.App, userUsecase usecase.UserUsecase) {
 	group := app.Group("/api/users", middleware.Protected())
 	group.Get("/me", handler.GetProfile)
 	group.Post("/become-creator", handler.BecomeCreator)
+	group.Post("/me/confirm-age", handler.ConfirmAge)
+	group.Put("/me/content-preferences", handler.UpdateContentPreferences)
 }
 
 func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
@@ -46,3 +50,53 @@ func (h *UserHandler) BecomeCreator(c *fiber.Ctx) error {
 
 	return c.JSON(fiber.Map{"message": "You are now a creator!"})
 }
+
+func (h *UserHandler) ConfirmAge(c *fiber.Ctx) error {
+	userIDStr := c.Locals("user_id").(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	type Request struct {
+		BirthDate string `json:"birth_date"` // YYYY-MM-DD
+	}
+	var req Request
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+	birthDate, err := time.Parse("2006-01-02", req.BirthDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "birth_date must be formatted as YYYY-MM-DD"})
+	}
+
+	user, err := h.userUsecase.ConfirmAge(userID, birthDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(user)
+}
+
+func (h *UserHandler) UpdateContentPreferences(c *fiber.Ctx) error {
+	userIDStr := c.Locals("user_id").(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	type Request struct {
+		ShowNSFW bool `json:"show_nsfw"`
+	}
+	var req Request
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	user, err := h.userUsecase.UpdateContentPreferences(userID, req.ShowNSFW)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(user)
+}
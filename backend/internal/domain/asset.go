@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// AssetKey is the storage key of an uploaded file, e.g. "3f2a….jpg". Keys
+// are what the database holds; JSON renders them as public URLs under the
+// asset base URL, so moving storage or putting a CDN in front only needs a
+// configuration change. Values that are already absolute URLs, such as
+// images hosted elsewhere, pass through unchanged.
+type AssetKey string
+
+var assetBaseURL = "http://localhost:8080/uploads/"
+
+// SetAssetBaseURL sets the URL keys are resolved against. It is called
+// once at start-up.
+func SetAssetBaseURL(base string) {
+	assetBaseURL = strings.TrimRight(base, "/") + "/"
+}
+
+// AssetBaseURL is the URL keys are currently resolved against.
+func AssetBaseURL() string {
+	return assetBaseURL
+}
+
+func (k AssetKey) IsExternal() bool {
+	s := string(k)
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "data:")
+}
+
+// URL is the public address of the asset.
+func (k AssetKey) URL() string {
+	if k == "" || k.IsExternal() {
+		return string(k)
+	}
+	return assetBaseURL + string(k)
+}
+
+// AssetKeyFromURL turns a public URL under the asset base URL back into its
+// key. Anything else is kept verbatim.
+func AssetKeyFromURL(url string) AssetKey {
+	if strings.HasPrefix(url, assetBaseURL) {
+		return AssetKey(strings.TrimPrefix(url, assetBaseURL))
+	}
+	return AssetKey(url)
+}
+
+func (k AssetKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.URL())
+}
+
+// UnmarshalJSON accepts the URLs clients got from upload responses.
+func (k *AssetKey) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*k = AssetKeyFromURL(s)
+	return nil
+}
+
+// ImageVariant is a downscaled copy of an image for narrower viewports.
+type ImageVariant struct {
+	Width  int      `json:"width"`
+	Height int      `json:"height"`
+	URL    AssetKey `json:"url"`
+}
+
+// ImageVariants is stored as JSONB holding keys; AssetKey's JSON form is
+// the resolved URL, so it can't be used for the column itself.
+type ImageVariants []ImageVariant
+
+type storedImageVariant struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Key    string `json:"key"`
+	// URL is how rows written before keys were introduced held the variant.
+	URL string `json:"url,omitempty"`
+}
+
+func (v ImageVariants) Value() (driver.Value, error) {
+	stored := make([]storedImageVariant, len(v))
+	for i, iv := range v {
+		stored[i] = storedImageVariant{Width: iv.Width, Height: iv.Height, Key: string(iv.URL)}
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (v *ImageVariants) Scan(src interface{}) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return errors.New("unsupported type for ImageVariants")
+	}
+	var stored []storedImageVariant
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	out := make(ImageVariants, len(stored))
+	for i, s := range stored {
+		key := AssetKey(s.Key)
+		if key == "" {
+			key = AssetKeyFromURL(s.URL)
+		}
+		out[i] = ImageVariant{Width: s.Width, Height: s.Height, URL: key}
+	}
+	*v = out
+	return nil
+}
+
+// ImageTile is one horizontal slice of a tall image: rows Y to Y+Height of
+// the original, at its full width. Stacked in order, the tiles reproduce
+// the original, so positions relative to it, such as text layers, apply
+// unchanged.
+type ImageTile struct {
+	Y      int      `json:"y"`
+	Width  int      `json:"width"`
+	Height int      `json:"height"`
+	URL    AssetKey `json:"url"`
+}
+
+// ImageTiles is stored as JSONB holding keys, like ImageVariants.
+type ImageTiles []ImageTile
+
+type storedImageTile struct {
+	Y      int    `json:"y"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Key    string `json:"key"`
+}
+
+func (t ImageTiles) Value() (driver.Value, error) {
+	stored := make([]storedImageTile, len(t))
+	for i, it := range t {
+		stored[i] = storedImageTile{Y: it.Y, Width: it.Width, Height: it.Height, Key: string(it.URL)}
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (t *ImageTiles) Scan(src interface{}) error {
+	var data []byte
+	switch s := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		data = s
+	case string:
+		data = []byte(s)
+	default:
+		return errors.New("unsupported type for ImageTiles")
+	}
+	var stored []storedImageTile
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	out := make(ImageTiles, len(stored))
+	for i, s := range stored {
+		out[i] = ImageTile{Y: s.Y, Width: s.Width, Height: s.Height, URL: AssetKey(s.Key)}
+	}
+	*t = out
+	return nil
+}
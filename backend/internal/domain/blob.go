@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// Blob is a stored image together with its thumbnail, reader variants and
+// tiles, addressed by the SHA-256 of the original's content so identical
+// uploads share one set of files. Blobs aren't reference-counted in a column:
+// references are the Series image fields and ChapterImage rows pointing at
+// its keys, counted when something lets go of them.
+type Blob struct {
+	Hash         string        `gorm:"primaryKey;size:64"`
+	OriginalKey  AssetKey      `gorm:"not null"`
+	Size         int64         `gorm:"not null"`
+	Width        int           `gorm:"not null"`
+	Height       int           `gorm:"not null"`
+	ThumbnailKey AssetKey      `gorm:"not null"`
+	Variants     ImageVariants `gorm:"type:jsonb"`
+	Tiles        ImageTiles    `gorm:"type:jsonb"`
+	CreatedAt    time.Time
+	// UpdatedAt is refreshed every time the content is uploaded again, so a
+	// fresh upload that isn't referenced yet is not mistaken for garbage.
+	UpdatedAt time.Time `gorm:"index"`
+}
+
+// StorageKeys lists every file stored for the blob.
+func (b *Blob) StorageKeys() []string {
+	keys := []string{string(b.OriginalKey), string(b.ThumbnailKey)}
+	for _, v := range b.Variants {
+		keys = append(keys, string(v.URL))
+	}
+	for _, t := range b.Tiles {
+		keys = append(keys, string(t.URL))
+	}
+	return keys
+}
+
+type BlobRepository interface {
+	// TouchBlob marks the blob as just uploaded and returns it, or
+	// gorm.ErrRecordNotFound if the content hasn't been stored before.
+	TouchBlob(hash string) (*Blob, error)
+	GetBlob(hash string) (*Blob, error)
+	// UpdateBlobTiles records tiles rendered for a blob stored before
+	// pages were tiled.
+	UpdateBlobTiles(hash string, tiles ImageTiles) error
+	// SaveBlob records a newly stored blob; saving one that already exists
+	// only refreshes it.
+	SaveBlob(blob *Blob) error
+	// DeleteBlobIfUnreferenced removes the blob when nothing references it
+	// and it was last uploaded before uploadedBefore. deleteFiles runs
+	// while the blob row is locked, so a concurrent re-upload of the same
+	// content waits and then stores the files afresh.
+	DeleteBlobIfUnreferenced(hash string, uploadedBefore time.Time, deleteFiles func(*Blob) error) (bool, error)
+	// ListUnreferencedBlobs returns blobs nothing references that were last
+	// uploaded before uploadedBefore.
+	ListUnreferencedBlobs(uploadedBefore time.Time) ([]Blob, error)
+	// ListReferencedKeys returns every storage key the database points at:
+	// series and chapter page images with their variants and tiles, blob
+	// files and resumable upload chunks. Rows not yet migrated to keys
+	// yield URLs.
+	ListReferencedKeys() ([]string, error)
+}
@@ -12,12 +12,14 @@ import (
 
 type SeriesStatus string
 type ChapterStatus string
+type WatermarkMode string
 
 const (
 	SeriesDraft     SeriesStatus = "draft"
 	SeriesPublished SeriesStatus = "published"
 	SeriesHiatus    SeriesStatus = "hiatus"
 	SeriesCompleted SeriesStatus = "completed"
+	SeriesArchived  SeriesStatus = "archived"
 
 	VisibilityPublic   = "public"
 	VisibilityPrivate  = "private"
@@ -26,8 +28,28 @@ const (
 	ChapterDraft     ChapterStatus = "draft"
 	ChapterPublished ChapterStatus = "published"
 	ChapterScheduled ChapterStatus = "scheduled"
+
+	// Watermark modes say how pages are marked when served to a signed-in
+	// reader.
+	WatermarkNone      WatermarkMode = "none"
+	WatermarkVisible   WatermarkMode = "visible"
+	WatermarkInvisible WatermarkMode = "invisible"
+	WatermarkBoth      WatermarkMode = "both"
 )
 
+// Marks reports whether pages are to be marked at all.
+func (m WatermarkMode) Marks() bool {
+	return m != "" && m != WatermarkNone
+}
+
+func (m WatermarkMode) Visible() bool {
+	return m == WatermarkVisible || m == WatermarkBoth
+}
+
+func (m WatermarkMode) Invisible() bool {
+	return m == WatermarkInvisible || m == WatermarkBoth
+}
+
 type MultilingualText struct {
 	En string `json:"en"`
 	Th string `json:"th"`
@@ -57,21 +79,31 @@ type Series struct {
 	Author              string           `json:"author"`
 	Genres              pq.StringArray   `gorm:"type:text[]" json:"genres"`
 	Tags                []Tag            `gorm:"many2many:series_tags;" json:"tags"`
-	ThumbnailURL        string           `json:"thumbnail_url"`
-	CoverImageURL       string           `json:"cover_image_url"`
-	BannerImageURL      string           `json:"banner_image_url"`
+	ThumbnailURL        AssetKey         `json:"thumbnail_url"`
+	CoverImageURL       AssetKey         `json:"cover_image_url"`
+	BannerImageURL      AssetKey         `json:"banner_image_url"`
 	Status              SeriesStatus     `gorm:"default:'draft'" json:"status"`
 	Visibility          string           `gorm:"default:'public'" json:"visibility"`
 	NSFW                bool             `gorm:"default:false" json:"nsfw"`
+	ViewCount           int64            `gorm:"default:0;index" json:"view_count"`
 	SchedulePublishAt   *time.Time       `json:"schedule_publish_at"`
 	MonetizationEnabled bool             `gorm:"default:false" json:"monetization_enabled"`
 	MonetizationType    string           `json:"monetization_type"`
 	DefaultUnlockType   string           `json:"default_unlock_type"`
+	Watermark           WatermarkMode    `gorm:"default:'none'" json:"watermark"`
 	CreatedAt           time.Time        `json:"created_at"`
 	UpdatedAt           time.Time        `json:"updated_at"`
 	Seasons             []Season         `json:"seasons,omitempty"`
 }
 
+// SeriesCollaborator lets a user other than the creator edit the text
+// layers and translations of a series' pages.
+type SeriesCollaborator struct {
+	SeriesID  uuid.UUID `gorm:"type:uuid;primaryKey" json:"series_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type Tag struct {
 	ID           uuid.UUID        `gorm:"type:uuid;primary_key;" json:"id"`
 	Slug         string           `gorm:"uniqueIndex;not null" json:"slug"`
@@ -96,26 +128,180 @@ type Season struct {
 }
 
 type Chapter struct {
-	ID            uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
-	SeasonID      uuid.UUID      `gorm:"type:uuid;not null" json:"season_id"`
-	ChapterNumber int            `gorm:"not null" json:"chapter_number"`
-	Title         string         `json:"title"`
-	Status        ChapterStatus  `gorm:"default:'draft'" json:"status"`
-	PublishedAt   *time.Time     `json:"published_at"`
-	Images        []ChapterImage `json:"images,omitempty"`
+	ID                uuid.UUID      `gorm:"type:uuid;primary_key;" json:"id"`
+	SeasonID          uuid.UUID      `gorm:"type:uuid;not null" json:"season_id"`
+	ChapterNumber     int            `gorm:"not null" json:"chapter_number"`
+	Title             string         `json:"title"`
+	Status            ChapterStatus  `gorm:"default:'draft'" json:"status"`
+	SchedulePublishAt *time.Time     `json:"schedule_publish_at"`
+	PublishedAt       *time.Time     `json:"published_at"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	Images            []ChapterImage `json:"images,omitempty"`
 }
 
 type ChapterImage struct {
-	ID         uuid.UUID   `gorm:"type:uuid;primary_key;" json:"id"`
-	ChapterID  uuid.UUID   `gorm:"type:uuid;not null" json:"chapter_id"`
-	ImageURL   string      `gorm:"not null" json:"image_url"`
-	Order      int         `gorm:"not null" json:"order"`
-	TextLayers []TextLayer `json:"text_layers,omitempty"`
+	ID           uuid.UUID     `gorm:"type:uuid;primary_key;" json:"id"`
+	ChapterID    uuid.UUID     `gorm:"type:uuid;not null" json:"chapter_id"`
+	ImageURL     AssetKey      `gorm:"not null" json:"image_url"`
+	Width        int           `json:"width"`
+	Height       int           `json:"height"`
+	ThumbnailURL AssetKey      `json:"thumbnail_url"`
+	Variants     ImageVariants `gorm:"type:jsonb" json:"variants"`
+	Tiles        ImageTiles    `gorm:"type:jsonb" json:"tiles"`
+	Order        int           `gorm:"not null" json:"order"`
+	TextLayers   []TextLayer   `json:"text_layers,omitempty"`
+}
+
+type SeriesSort string
+
+const (
+	SortNewest  SeriesSort = "newest"
+	SortUpdated SeriesSort = "updated"
+	SortPopular SeriesSort = "popular"
+	SortTitle   SeriesSort = "title"
+)
+
+const (
+	DefaultSeriesPageSize = 20
+	MaxSeriesPageSize     = 100
+)
+
+// SeriesCursor marks the last row of a page: the value of the sort key and
+// the ID used to break ties.
+type SeriesCursor struct {
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// SeriesQuery narrows and orders the public series listing.
+type SeriesQuery struct {
+	Statuses     []SeriesStatus
+	Visibilities []string
+	// NSFW restricts the listing to NSFW (true) or safe (false) series;
+	// nil includes both.
+	NSFW        *bool
+	Genre       string
+	TagSlug     string
+	CreatorID   *uuid.UUID
+	AvailableIn string // language code that must have a title, e.g. "th"
+	Sort        SeriesSort
+	Locale      string // title language used by SortTitle
+	Cursor      *SeriesCursor
+	Limit       int
+}
+
+// SeriesPage is one page of a cursor-paginated series listing. NextCursor
+// is empty on the last page.
+type SeriesPage struct {
+	Items      []Series `json:"items"`
+	NextCursor string   `json:"next_cursor"`
+}
+
+// SeriesSummary is the part of a series the reader page needs alongside a
+// chapter.
+type SeriesSummary struct {
+	ID           uuid.UUID        `json:"id"`
+	CreatorID    uuid.UUID        `json:"creator_id"`
+	Title        MultilingualText `json:"title"`
+	Author       string           `json:"author"`
+	ThumbnailURL AssetKey         `json:"thumbnail_url"`
+}
+
+type SeasonSummary struct {
+	ID           uuid.UUID `json:"id"`
+	SeasonNumber int       `json:"season_number"`
+	Title        string    `json:"title"`
+}
+
+// ChapterRef locates a chapter within its series' reading order.
+type ChapterRef struct {
+	ID            uuid.UUID
+	SeasonNumber  int
+	ChapterNumber int
+}
+
+// Before reports whether r comes before other in reading order.
+func (r ChapterRef) Before(other ChapterRef) bool {
+	if r.SeasonNumber != other.SeasonNumber {
+		return r.SeasonNumber < other.SeasonNumber
+	}
+	return r.ChapterNumber < other.ChapterNumber
+}
+
+// ChapterView is a chapter as served to the reader, with its series and
+// season and the neighbouring published chapters across season boundaries.
+type ChapterView struct {
+	*Chapter
+	Series        SeriesSummary `json:"series"`
+	Season        SeasonSummary `json:"season"`
+	PrevChapterID *uuid.UUID    `json:"prev_chapter_id"`
+	NextChapterID *uuid.UUID    `json:"next_chapter_id"`
+}
+
+// CreatorSeriesSummary is a series as shown on its creator's dashboard.
+type CreatorSeriesSummary struct {
+	Series
+	ChapterCount       int      `json:"chapter_count"`
+	LastUpdatedChapter *Chapter `json:"last_updated_chapter"`
 }
 
 type ComicRepository interface {
 	CreateSeries(series *Series) error
 	GetSeriesByID(id uuid.UUID) (*Series, error)
 	GetChapterByID(id uuid.UUID) (*Chapter, error)
-	ListSeries() ([]Series, error)
+	// ListPublishedChapterRefs returns the series' published chapters in
+	// reading order.
+	ListPublishedChapterRefs(seriesID uuid.UUID) ([]ChapterRef, error)
+	// ListSeries returns up to query.Limit series after query.Cursor.
+	ListSeries(query SeriesQuery) ([]Series, error)
+	IncrementSeriesViews(id uuid.UUID) error
+	ListSeriesByCreator(creatorID uuid.UUID) ([]CreatorSeriesSummary, error)
+	UpdateSeries(series *Series) error
+	DeleteSeries(id uuid.UUID) error
+	// PublishScheduled publishes series and chapters whose scheduled time is
+	// at or before now. It is a no-op if another instance holds the lock.
+	PublishScheduled(now time.Time) (seriesCount, chapterCount int64, err error)
+
+	CreateSeason(season *Season) error
+	GetSeasonByID(id uuid.UUID) (*Season, error)
+	ListSeasonsBySeriesID(seriesID uuid.UUID) ([]Season, error)
+	UpdateSeason(season *Season) error
+	DeleteSeason(id uuid.UUID) error
+
+	CreateChapter(chapter *Chapter) error
+	// CreateChapters creates all the chapters, with their pages, or none.
+	CreateChapters(chapters []Chapter) error
+	UpdateChapter(chapter *Chapter) error
+	DeleteChapter(id uuid.UUID) error
+
+	GetChapterImageByID(id uuid.UUID) (*ChapterImage, error)
+	// GetSeriesByChapterImageID returns the series a page belongs to,
+	// without its seasons or tags.
+	GetSeriesByChapterImageID(imageID uuid.UUID) (*Series, error)
+	AddChapterImages(chapterID uuid.UUID, images []ChapterImage) error
+	ReorderChapterImages(chapterID uuid.UUID, imageIDs []uuid.UUID) ([]ChapterImage, error)
+	UpdateChapterImage(image *ChapterImage) error
+	UpdateChapterImageTiles(id uuid.UUID, tiles ImageTiles) error
+	// ListUntiledChapterImages returns pages taller than minHeight that
+	// have no tiles, such as those stored before pages were tiled.
+	ListUntiledChapterImages(minHeight int) ([]ChapterImage, error)
+	DeleteChapterImage(id uuid.UUID) error
+	// ListChapterImageKeys returns the image keys of every page in the
+	// given chapters.
+	ListChapterImageKeys(chapterIDs []uuid.UUID) ([]AssetKey, error)
+	// IsProtectedPageKey reports whether key is a page, thumbnail, variant
+	// or tile of a chapter in a monetized or watermarked series and isn't
+	// also series art.
+	IsProtectedPageKey(key AssetKey) (bool, error)
+	// HasProtectedSeries reports whether any series is monetized or
+	// watermarked.
+	HasProtectedSeries() (bool, error)
+
+	ListSeriesCollaborators(seriesID uuid.UUID) ([]SeriesCollaborator, error)
+	IsSeriesCollaborator(seriesID, userID uuid.UUID) (bool, error)
+	// AddSeriesCollaborator is a no-op if the user already collaborates on
+	// the series.
+	AddSeriesCollaborator(collaborator *SeriesCollaborator) error
+	RemoveSeriesCollaborator(seriesID, userID uuid.UUID) error
 }
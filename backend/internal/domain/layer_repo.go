@@ -6,6 +6,15 @@ import (
 
 type LayerRepository interface {
 	CreateLayer(layer *TextLayer) error
+	GetLayerByID(id uuid.UUID) (*TextLayer, error)
 	GetLayersByImageID(imageID uuid.UUID) ([]TextLayer, error)
+	UpdateLayer(layer *TextLayer) error
+	// DeleteLayer removes a layer together with its translations.
+	DeleteLayer(id uuid.UUID) error
+	// ReplaceLayers makes layers the complete set on the image in one
+	// transaction. Layers whose ID is already on the image are updated and
+	// keep their translations; the rest are created with fresh IDs, and
+	// layers left out are deleted.
+	ReplaceLayers(imageID uuid.UUID, layers []TextLayer) error
 	CreateTranslation(translation *Translation) error
 }
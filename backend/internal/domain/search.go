@@ -0,0 +1,33 @@
+package domain
+
+import "github.com/google/uuid"
+
+// SearchQuery is a reader's search together with the visibility policy the
+// results must respect.
+type SearchQuery struct {
+	Text string
+	// Terms are the whitespace-separated parts of Text. Each must appear as
+	// a substring, which is how Thai (written without spaces) is matched.
+	Terms        []string
+	Statuses     []SeriesStatus
+	Visibilities []string
+	NSFW         *bool
+	Limit        int
+}
+
+// DialogueMatch is a text layer whose original text or translation matched
+// a search.
+type DialogueMatch struct {
+	SeriesID       uuid.UUID `json:"series_id"`
+	ChapterID      uuid.UUID `json:"chapter_id"`
+	ChapterImageID uuid.UUID `json:"chapter_image_id"`
+	TextLayerID    uuid.UUID `json:"text_layer_id"`
+	Text           string    `json:"text"`
+	LanguageCode   string    `json:"language_code"` // empty for the original text
+}
+
+type SearchRepository interface {
+	SearchSeries(query SearchQuery) ([]Series, error)
+	// SearchDialogue only matches layers in published chapters.
+	SearchDialogue(query SearchQuery) ([]DialogueMatch, error)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo describes a stored file.
+type ObjectInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// FileStorage stores uploaded files under slash-separated keys and serves
+// them from a public URL.
+type FileStorage interface {
+	Put(key string, r io.Reader, size int64, contentType string) error
+	// Get returns ErrObjectNotFound if key does not exist.
+	Get(key string) (io.ReadCloser, error)
+	// Delete succeeds if key does not exist.
+	Delete(key string) error
+	// URL is the public address of key.
+	URL(key string) string
+	// List calls fn for every stored file whose key starts with prefix,
+	// stopping at the first error fn returns.
+	List(prefix string, fn func(ObjectInfo) error) error
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TagAlias is an alternative slug that resolves to a canonical tag, e.g. the
+// slug of a tag that was merged into another.
+type TagAlias struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;" json:"id"`
+	TagID     uuid.UUID `gorm:"type:uuid;not null;index" json:"tag_id"`
+	Slug      string    `gorm:"uniqueIndex;not null" json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TagRepository interface {
+	CreateTag(tag *Tag) error
+	GetTagByID(id uuid.UUID) (*Tag, error)
+	// FindTagBySlug looks the slug up among canonical tags first, then aliases.
+	FindTagBySlug(slug string) (*Tag, error)
+	ListTags() ([]Tag, error)
+	AddTranslation(translation *TagTranslation) error
+	CreateAlias(alias *TagAlias) error
+	// MergeTags moves every series and alias from source to target, along
+	// with names in languages target has none in, keeps the source slug as
+	// an alias of target, and deletes source.
+	MergeTags(sourceID, targetID uuid.UUID) error
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrStaleUploadOffset means another chunk was appended to the session
+// first, so the one being written no longer starts at the session's offset.
+var ErrStaleUploadOffset = errors.New("upload offset has moved")
+
+// UploadSession is a resumable upload in progress. Each received chunk is
+// kept as its own storage object until the upload is completed, so any
+// server instance can accept the next chunk.
+type UploadSession struct {
+	ID        uuid.UUID     `gorm:"type:uuid;primary_key;" json:"id"`
+	UserID    uuid.UUID     `gorm:"type:uuid;not null;index" json:"user_id"`
+	Filename  string        `gorm:"not null" json:"filename"`
+	Size      int64         `gorm:"not null" json:"size"`
+	Offset    int64         `gorm:"column:upload_offset;not null;default:0" json:"offset"`
+	ExpiresAt time.Time     `gorm:"not null;index" json:"expires_at"`
+	Chunks    []UploadChunk `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+func (s *UploadSession) Complete() bool {
+	return s.Offset == s.Size
+}
+
+// UploadChunk is one received byte range of an UploadSession.
+type UploadChunk struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;"`
+	SessionID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_upload_chunk_offset"`
+	Offset    int64     `gorm:"column:byte_offset;not null;uniqueIndex:idx_upload_chunk_offset"`
+	Size      int64     `gorm:"not null"`
+	Key       string    `gorm:"not null"`
+}
+
+type UploadSessionRepository interface {
+	CreateSession(session *UploadSession) error
+	// GetSession loads a session with its chunks in offset order.
+	GetSession(id uuid.UUID) (*UploadSession, error)
+	// AppendChunk records chunk at the end of the session and extends its
+	// expiry. It returns ErrStaleUploadOffset if the session's offset is no
+	// longer chunk.Offset.
+	AppendChunk(chunk *UploadChunk, expiresAt time.Time) error
+	DeleteSession(id uuid.UUID) error
+	ListExpiredSessions(now time.Time, limit int) ([]UploadSession, error)
+}
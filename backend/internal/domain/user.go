@@ -20,8 +20,21 @@ type User struct {
 	Email        string    `gorm:"unique;not null" json:"email"`
 	PasswordHash string    `gorm:"not null" json:"-"`
 	Role         UserRole  `gorm:"default:'user'" json:"role"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	// ShowNSFW is the reader's content filter preference. It only takes
+	// effect once AgeConfirmedAt is set.
+	ShowNSFW       bool       `gorm:"default:false" json:"show_nsfw"`
+	AgeConfirmedAt *time.Time `json:"age_confirmed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// MinimumNSFWAge is the age a reader must confirm before NSFW series are shown.
+const MinimumNSFWAge = 18
+
+// CanViewNSFW reports whether the user has confirmed their age and opted in
+// to NSFW content.
+func (u *User) CanViewNSFW() bool {
+	return u.AgeConfirmedAt != nil && u.ShowNSFW
 }
 
 type UserRepository interface {
@@ -17,14 +17,7 @@ func Protected() fiber.Handler {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Missing authorization header"})
 		}
 
-		tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
-
+		token, err := parseToken(authHeader)
 		if err != nil || !token.Valid {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid token"})
 		}
@@ -41,6 +34,40 @@ func Protected() fiber.Handler {
 	}
 }
 
+// OptionalAuth identifies the user when a valid token is sent but lets
+// anonymous requests through, so public routes can tailor their response
+// (e.g. letting owners preview drafts).
+func OptionalAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authHeader := c.Get("Authorization")
+		if authHeader == "" {
+			return c.Next()
+		}
+
+		token, err := parseToken(authHeader)
+		if err != nil || !token.Valid {
+			return c.Next()
+		}
+
+		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			c.Locals("user_id", claims["user_id"])
+			c.Locals("role", claims["role"])
+		}
+
+		return c.Next()
+	}
+}
+
+func parseToken(authHeader string) (*jwt.Token, error) {
+	tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
+	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(os.Getenv("JWT_SECRET")), nil
+	})
+}
+
 func RoleRequired(roles ...domain.UserRole) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		userRole := c.Locals("role").(string)
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type blobRepository struct {
+	db *gorm.DB
+}
+
+func NewBlobRepository(db *gorm.DB) domain.BlobRepository {
+	return &blobRepository{db}
+}
+
+func (r *blobRepository) TouchBlob(hash string) (*domain.Blob, error) {
+	var blob domain.Blob
+	res := r.db.Model(&blob).
+		Clauses(clause.Returning{}).
+		Where("hash = ?", hash).
+		Update("updated_at", time.Now())
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &blob, nil
+}
+
+func (r *blobRepository) GetBlob(hash string) (*domain.Blob, error) {
+	var blob domain.Blob
+	if err := r.db.Where("hash = ?", hash).First(&blob).Error; err != nil {
+		return nil, err
+	}
+	return &blob, nil
+}
+
+func (r *blobRepository) UpdateBlobTiles(hash string, tiles domain.ImageTiles) error {
+	return r.db.Model(&domain.Blob{}).Where("hash = ?", hash).Update("tiles", tiles).Error
+}
+
+func (r *blobRepository) SaveBlob(blob *domain.Blob) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "hash"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"updated_at": blob.UpdatedAt}),
+	}).Create(blob).Error
+}
+
+// blobReferenced matches blobs that a series or chapter page still points
+// at. Every key of a blob starts with its hash. Each column gets its own
+// EXISTS so Postgres can use the matching index from ensureBlobIndexes.
+var blobReferenced = func() string {
+	var conds []string
+	for _, table := range blobReferenceColumns {
+		for _, col := range table.columns {
+			conds = append(conds, fmt.Sprintf("EXISTS (SELECT 1 FROM %s WHERE left(%s, 64) = blobs.hash)", table.name, col))
+		}
+	}
+	return strings.Join(conds, " OR ")
+}()
+
+// blobReferenceColumns are the columns holding keys of stored images.
+var blobReferenceColumns = []struct {
+	name    string
+	columns []string
+}{
+	{"series", []string{"thumbnail_url", "cover_image_url", "banner_image_url"}},
+	{"chapter_images", []string{"image_url", "thumbnail_url"}},
+}
+
+// ensureBlobIndexes indexes the hash prefix of every column blobReferenced
+// checks, so releasing an image doesn't scan whole tables while it holds
+// the blob's lock.
+func ensureBlobIndexes(db *gorm.DB) {
+	for _, table := range blobReferenceColumns {
+		for _, col := range table.columns {
+			stmt := fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%[1]s_%[2]s_hash ON %[1]s (left(%[2]s, 64))", table.name, col)
+			if err := db.Exec(stmt).Error; err != nil {
+				log.Println("Failed to create blob reference index, releasing images will be slower: ", err)
+			}
+		}
+	}
+}
+
+func (r *blobRepository) DeleteBlobIfUnreferenced(hash string, uploadedBefore time.Time, deleteFiles func(*domain.Blob) error) (bool, error) {
+	deleted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var blob domain.Blob
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("hash = ? AND updated_at < ?", hash, uploadedBefore).
+			Where("NOT (" + blobReferenced + ")").
+			First(&blob).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := deleteFiles(&blob); err != nil {
+			return err
+		}
+		if err := tx.Delete(&blob).Error; err != nil {
+			return err
+		}
+		deleted = true
+		return nil
+	})
+	return deleted, err
+}
+
+func (r *blobRepository) ListUnreferencedBlobs(uploadedBefore time.Time) ([]domain.Blob, error) {
+	var blobs []domain.Blob
+	err := r.db.Where("updated_at < ?", uploadedBefore).
+		Where("NOT (" + blobReferenced + ")").
+		Order("hash").
+		Find(&blobs).Error
+	return blobs, err
+}
+
+// jsonKeys expands a variants or tiles JSONB column into its keys, reading
+// the legacy "url" field where "key" is missing.
+const jsonKeys = `
+	SELECT coalesce(v->>'key', v->>'url') FROM %[1]s,
+		jsonb_array_elements(CASE WHEN jsonb_typeof(%[2]s) = 'array' THEN %[2]s ELSE '[]'::jsonb END) v`
+
+func (r *blobRepository) ListReferencedKeys() ([]string, error) {
+	query := strings.Join([]string{
+		"SELECT thumbnail_url FROM series",
+		"SELECT cover_image_url FROM series",
+		"SELECT banner_image_url FROM series",
+		"SELECT image_url FROM chapter_images",
+		"SELECT thumbnail_url FROM chapter_images",
+		fmt.Sprintf(jsonKeys, "chapter_images", "variants"),
+		fmt.Sprintf(jsonKeys, "chapter_images", "tiles"),
+		"SELECT original_key FROM blobs",
+		"SELECT thumbnail_key FROM blobs",
+		fmt.Sprintf(jsonKeys, "blobs", "variants"),
+		fmt.Sprintf(jsonKeys, "blobs", "tiles"),
+		"SELECT key FROM upload_chunks",
+	}, " UNION ")
+
+	var keys []string
+	err := r.db.Raw("SELECT k FROM (" + query + ") refs(k) WHERE k IS NOT NULL AND k <> ''").
+		Scan(&keys).Error
+	return keys, err
+}
@@ -1,9 +1,17 @@
 package postgres
 
 import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/pur108/ebook-platform/backend/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type comicRepository struct {
@@ -20,7 +28,11 @@ func (r *comicRepository) CreateSeries(series *domain.Series) error {
 
 func (r *comicRepository) GetSeriesByID(id uuid.UUID) (*domain.Series, error) {
 	var series domain.Series
-	err := r.db.Preload("Seasons.Chapters").Preload("Tags.Translations").First(&series, id).Error
+	err := r.db.Preload("Seasons", func(db *gorm.DB) *gorm.DB {
+		return db.Order("season_number asc")
+	}).Preload("Seasons.Chapters", func(db *gorm.DB) *gorm.DB {
+		return db.Order("chapter_number asc")
+	}).Preload("Tags.Translations").First(&series, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -29,19 +41,598 @@ func (r *comicRepository) GetSeriesByID(id uuid.UUID) (*domain.Series, error) {
 
 func (r *comicRepository) GetChapterByID(id uuid.UUID) (*domain.Chapter, error) {
 	var chapter domain.Chapter
-	err := r.db.Preload("Images.TextLayers.Translations").First(&chapter, id).Error
+	err := r.db.Preload("Images", func(db *gorm.DB) *gorm.DB {
+		return db.Order("\"order\" asc")
+	}).Preload("Images.TextLayers.Translations").First(&chapter, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &chapter, nil
 }
 
-func (r *comicRepository) ListSeries() ([]domain.Series, error) {
+func (r *comicRepository) ListPublishedChapterRefs(seriesID uuid.UUID) ([]domain.ChapterRef, error) {
+	var refs []domain.ChapterRef
+	err := r.db.Model(&domain.Chapter{}).
+		Select("chapters.id, seasons.season_number, chapters.chapter_number").
+		Joins("JOIN seasons ON seasons.id = chapters.season_id").
+		Where("seasons.series_id = ? AND chapters.status = ?", seriesID, domain.ChapterPublished).
+		Order("seasons.season_number asc, chapters.chapter_number asc").
+		Scan(&refs).Error
+	if err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+func (r *comicRepository) ListSeries(query domain.SeriesQuery) ([]domain.Series, error) {
 	var series []domain.Series
-	// Limit to 20 for now, order by updated_at desc
-	err := r.db.Preload("Tags.Translations").Order("updated_at desc").Limit(20).Find(&series).Error
+	db := r.db.Preload("Tags.Translations")
+	if len(query.Statuses) > 0 {
+		db = db.Where("status IN ?", query.Statuses)
+	}
+	if len(query.Visibilities) > 0 {
+		db = db.Where("visibility IN ?", query.Visibilities)
+	}
+	if query.NSFW != nil {
+		db = db.Where("nsfw = ?", *query.NSFW)
+	}
+	if query.Genre != "" {
+		db = db.Where("? = ANY(genres)", query.Genre)
+	}
+	if query.TagSlug != "" {
+		db = db.Where(`EXISTS (
+			SELECT 1 FROM series_tags st JOIN tags t ON t.id = st.tag_id
+			WHERE st.series_id = series.id
+			AND (t.slug = ? OR t.id IN (SELECT tag_id FROM tag_aliases WHERE slug = ?)))`,
+			query.TagSlug, query.TagSlug)
+	}
+	if query.CreatorID != nil {
+		db = db.Where("creator_id = ?", *query.CreatorID)
+	}
+	if query.AvailableIn != "" {
+		db = db.Where("COALESCE(title->>?, '') <> ''", query.AvailableIn)
+	}
+
+	key, desc := seriesSortKey(query)
+	if query.Cursor != nil {
+		value, err := seriesCursorValue(query)
+		if err != nil {
+			return nil, err
+		}
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		db = db.Where(fmt.Sprintf("(%s, series.id) %s (?, ?)", key, op), value, query.Cursor.ID)
+	}
+	dir := "ASC"
+	if desc {
+		dir = "DESC"
+	}
+
+	err := db.Order(fmt.Sprintf("%s %s, series.id %s", key, dir, dir)).Limit(query.Limit).Find(&series).Error
 	if err != nil {
 		return nil, err
 	}
 	return series, nil
 }
+
+// seriesSortKey returns the SQL expression a listing is ordered by and
+// whether the order is descending.
+func seriesSortKey(query domain.SeriesQuery) (string, bool) {
+	switch query.Sort {
+	case domain.SortNewest:
+		return "series.created_at", true
+	case domain.SortPopular:
+		return "series.view_count", true
+	case domain.SortTitle:
+		if query.Locale == "th" {
+			return "COALESCE(NULLIF(series.title->>'th', ''), series.title->>'en')", false
+		}
+		return "COALESCE(NULLIF(series.title->>'en', ''), series.title->>'th')", false
+	default:
+		return "series.updated_at", true
+	}
+}
+
+// seriesCursorValue converts the cursor's string value to the Go type of
+// the sort key so it binds with the right Postgres type.
+func seriesCursorValue(query domain.SeriesQuery) (interface{}, error) {
+	switch query.Sort {
+	case domain.SortPopular:
+		return strconv.ParseInt(query.Cursor.Value, 10, 64)
+	case domain.SortTitle:
+		return query.Cursor.Value, nil
+	default:
+		return time.Parse(time.RFC3339Nano, query.Cursor.Value)
+	}
+}
+
+func (r *comicRepository) IncrementSeriesViews(id uuid.UUID) error {
+	return r.db.Model(&domain.Series{}).Where("id = ?", id).
+		UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error
+}
+
+// ListSeriesByCreator returns every series owned by creatorID regardless of
+// status or visibility, with chapter counts and the most recently updated
+// chapter of each.
+func (r *comicRepository) ListSeriesByCreator(creatorID uuid.UUID) ([]domain.CreatorSeriesSummary, error) {
+	var series []domain.Series
+	err := r.db.Preload("Tags.Translations").
+		Where("creator_id = ?", creatorID).
+		Order("updated_at desc").
+		Find(&series).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(series) == 0 {
+		return []domain.CreatorSeriesSummary{}, nil
+	}
+
+	seriesIDs := make([]uuid.UUID, len(series))
+	for i, s := range series {
+		seriesIDs[i] = s.ID
+	}
+
+	type chapterCount struct {
+		SeriesID uuid.UUID
+		Count    int
+	}
+	var counts []chapterCount
+	err = r.db.Model(&domain.Chapter{}).
+		Select("seasons.series_id, COUNT(chapters.id) AS count").
+		Joins("JOIN seasons ON seasons.id = chapters.season_id").
+		Where("seasons.series_id IN ?", seriesIDs).
+		Group("seasons.series_id").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	type lastChapter struct {
+		domain.Chapter
+		SeriesID uuid.UUID
+	}
+	var latest []lastChapter
+	err = r.db.Raw(`
+		SELECT DISTINCT ON (seasons.series_id) chapters.*, seasons.series_id
+		FROM chapters
+		JOIN seasons ON seasons.id = chapters.season_id
+		WHERE seasons.series_id IN ?
+		ORDER BY seasons.series_id, chapters.updated_at DESC`, seriesIDs).
+		Scan(&latest).Error
+	if err != nil {
+		return nil, err
+	}
+
+	countBySeries := make(map[uuid.UUID]int, len(counts))
+	for _, c := range counts {
+		countBySeries[c.SeriesID] = c.Count
+	}
+	latestBySeries := make(map[uuid.UUID]*domain.Chapter, len(latest))
+	for i := range latest {
+		latestBySeries[latest[i].SeriesID] = &latest[i].Chapter
+	}
+
+	summaries := make([]domain.CreatorSeriesSummary, len(series))
+	for i, s := range series {
+		summaries[i] = domain.CreatorSeriesSummary{
+			Series:             s,
+			ChapterCount:       countBySeries[s.ID],
+			LastUpdatedChapter: latestBySeries[s.ID],
+		}
+	}
+	return summaries, nil
+}
+
+// UpdateSeries saves the series' own columns and replaces its tag set with
+// series.Tags.
+func (r *comicRepository) UpdateSeries(series *domain.Series) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(series).Error; err != nil {
+			return err
+		}
+		return tx.Model(series).Association("Tags").Replace(series.Tags)
+	})
+}
+
+// DeleteSeries removes a series together with its seasons, chapters, pages,
+// text layers, translations and collaborators. Tags are shared and are left
+// in place.
+func (r *comicRepository) DeleteSeries(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		seasonIDs := tx.Model(&domain.Season{}).Select("id").Where("series_id = ?", id)
+		chapterIDs := tx.Model(&domain.Chapter{}).Select("id").Where("season_id IN (?)", seasonIDs)
+		if err := deleteChapterContent(tx, chapterIDs); err != nil {
+			return err
+		}
+		if err := tx.Where("season_id IN (?)", seasonIDs).Delete(&domain.Chapter{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("series_id = ?", id).Delete(&domain.Season{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM series_tags WHERE series_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("series_id = ?", id).Delete(&domain.SeriesCollaborator{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Series{}, id).Error
+	})
+}
+
+// publishLockKey identifies the Postgres advisory lock held while publishing
+// scheduled content, so only one server instance does it at a time.
+const publishLockKey = 7270501
+
+func (r *comicRepository) PublishScheduled(now time.Time) (int64, int64, error) {
+	var seriesCount, chapterCount int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", publishLockKey).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		// The status checks make both updates idempotent: a row that was
+		// already published is never matched again.
+		res := tx.Model(&domain.Series{}).
+			Where("status = ? AND schedule_publish_at IS NOT NULL AND schedule_publish_at <= ?", domain.SeriesDraft, now).
+			UpdateColumns(map[string]interface{}{
+				"status":              domain.SeriesPublished,
+				"schedule_publish_at": nil,
+				"updated_at":          now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		seriesCount = res.RowsAffected
+
+		res = tx.Model(&domain.Chapter{}).
+			Where("status = ? AND schedule_publish_at <= ?", domain.ChapterScheduled, now).
+			UpdateColumns(map[string]interface{}{
+				"status":              domain.ChapterPublished,
+				"published_at":        gorm.Expr("schedule_publish_at"),
+				"schedule_publish_at": nil,
+				"updated_at":          now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		chapterCount = res.RowsAffected
+		return nil
+	})
+	return seriesCount, chapterCount, err
+}
+
+func (r *comicRepository) CreateSeason(season *domain.Season) error {
+	return r.db.Create(season).Error
+}
+
+func (r *comicRepository) GetSeasonByID(id uuid.UUID) (*domain.Season, error) {
+	var season domain.Season
+	err := r.db.Preload("Chapters", func(db *gorm.DB) *gorm.DB {
+		return db.Order("chapter_number asc")
+	}).First(&season, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+func (r *comicRepository) ListSeasonsBySeriesID(seriesID uuid.UUID) ([]domain.Season, error) {
+	var seasons []domain.Season
+	err := r.db.Where("series_id = ?", seriesID).
+		Preload("Chapters", func(db *gorm.DB) *gorm.DB {
+			return db.Order("chapter_number asc")
+		}).
+		Order("season_number asc").
+		Find(&seasons).Error
+	if err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+func (r *comicRepository) UpdateSeason(season *domain.Season) error {
+	return r.db.Omit(clause.Associations).Save(season).Error
+}
+
+func (r *comicRepository) DeleteSeason(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		chapterIDs := tx.Model(&domain.Chapter{}).Select("id").Where("season_id = ?", id)
+		if err := deleteChapterContent(tx, chapterIDs); err != nil {
+			return err
+		}
+		if err := tx.Where("season_id = ?", id).Delete(&domain.Chapter{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Season{}, id).Error
+	})
+}
+
+func (r *comicRepository) CreateChapter(chapter *domain.Chapter) error {
+	return r.db.Create(chapter).Error
+}
+
+func (r *comicRepository) CreateChapters(chapters []domain.Chapter) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range chapters {
+			if err := tx.Create(&chapters[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *comicRepository) UpdateChapter(chapter *domain.Chapter) error {
+	return r.db.Omit(clause.Associations).Save(chapter).Error
+}
+
+func (r *comicRepository) DeleteChapter(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteChapterContent(tx, []uuid.UUID{id}); err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Chapter{}, id).Error
+	})
+}
+
+func (r *comicRepository) GetChapterImageByID(id uuid.UUID) (*domain.ChapterImage, error) {
+	var image domain.ChapterImage
+	err := r.db.First(&image, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+// AddChapterImages appends images to the end of a chapter, assigning each
+// the next Order value in slice order.
+func (r *comicRepository) AddChapterImages(chapterID uuid.UUID, images []domain.ChapterImage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockChapter(tx, chapterID); err != nil {
+			return err
+		}
+
+		var maxOrder int
+		err := tx.Model(&domain.ChapterImage{}).
+			Where("chapter_id = ?", chapterID).
+			Select("COALESCE(MAX(\"order\"), 0)").
+			Scan(&maxOrder).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range images {
+			images[i].ChapterID = chapterID
+			images[i].Order = maxOrder + i + 1
+		}
+		return tx.Create(&images).Error
+	})
+}
+
+// GetSeriesByChapterImageID loads only the series a page belongs to, without
+// its seasons and chapters.
+func (r *comicRepository) GetSeriesByChapterImageID(imageID uuid.UUID) (*domain.Series, error) {
+	var series domain.Series
+	err := r.db.Select("series.*").
+		Joins("JOIN seasons ON seasons.series_id = series.id").
+		Joins("JOIN chapters ON chapters.season_id = seasons.id").
+		Joins("JOIN chapter_images ON chapter_images.chapter_id = chapters.id").
+		Where("chapter_images.id = ?", imageID).
+		Take(&series).Error
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// ReorderChapterImages renumbers every page of a chapter to follow imageIDs.
+// imageIDs must list each of the chapter's images exactly once.
+func (r *comicRepository) ReorderChapterImages(chapterID uuid.UUID, imageIDs []uuid.UUID) ([]domain.ChapterImage, error) {
+	var images []domain.ChapterImage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockChapter(tx, chapterID); err != nil {
+			return err
+		}
+		if err := tx.Where("chapter_id = ?", chapterID).Find(&images).Error; err != nil {
+			return err
+		}
+
+		byID := make(map[uuid.UUID]*domain.ChapterImage, len(images))
+		for i := range images {
+			byID[images[i].ID] = &images[i]
+		}
+		if len(imageIDs) != len(images) {
+			return fmt.Errorf("expected %d image IDs, got %d", len(images), len(imageIDs))
+		}
+		for i, id := range imageIDs {
+			image, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("image %s is missing or listed twice", id)
+			}
+			delete(byID, id)
+			image.Order = i + 1
+			if err := tx.Model(image).Update("order", image.Order).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortImagesByOrder(images)
+	return images, nil
+}
+
+func (r *comicRepository) UpdateChapterImage(image *domain.ChapterImage) error {
+	return r.db.Omit(clause.Associations).Save(image).Error
+}
+
+func (r *comicRepository) UpdateChapterImageTiles(id uuid.UUID, tiles domain.ImageTiles) error {
+	return r.db.Model(&domain.ChapterImage{}).Where("id = ?", id).Update("tiles", tiles).Error
+}
+
+func (r *comicRepository) ListUntiledChapterImages(minHeight int) ([]domain.ChapterImage, error) {
+	var images []domain.ChapterImage
+	err := r.db.Where("height > ?", minHeight).
+		Where("tiles IS NULL OR NOT tiles @> '[{}]'::jsonb").
+		Order("chapter_id, \"order\"").
+		Find(&images).Error
+	return images, err
+}
+
+// DeleteChapterImage removes a page and closes the gap it leaves in Order.
+func (r *comicRepository) DeleteChapterImage(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var image domain.ChapterImage
+		if err := tx.First(&image, id).Error; err != nil {
+			return err
+		}
+		if err := lockChapter(tx, image.ChapterID); err != nil {
+			return err
+		}
+
+		layerIDs := tx.Model(&domain.TextLayer{}).Select("id").Where("chapter_image_id = ?", id)
+		if err := tx.Where("text_layer_id IN (?)", layerIDs).Delete(&domain.Translation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("chapter_image_id = ?", id).Delete(&domain.TextLayer{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&image).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&domain.ChapterImage{}).
+			Where("chapter_id = ? AND \"order\" > ?", image.ChapterID, image.Order).
+			Update("order", gorm.Expr("\"order\" - 1")).Error
+	})
+}
+
+func (r *comicRepository) ListChapterImageKeys(chapterIDs []uuid.UUID) ([]domain.AssetKey, error) {
+	var keys []domain.AssetKey
+	if len(chapterIDs) == 0 {
+		return keys, nil
+	}
+	err := r.db.Model(&domain.ChapterImage{}).
+		Where("chapter_id IN ?", chapterIDs).
+		Pluck("image_url", &keys).Error
+	return keys, err
+}
+
+func (r *comicRepository) IsProtectedPageKey(key domain.AssetKey) (bool, error) {
+	// Variants and tiles store their keys the same way.
+	element, err := json.Marshal([]map[string]string{{"key": string(key)}})
+	if err != nil {
+		return false, err
+	}
+	var premium bool
+	err = r.db.Raw(`
+		SELECT EXISTS (
+			SELECT 1 FROM chapter_images ci
+			JOIN chapters c ON c.id = ci.chapter_id
+			JOIN seasons s ON s.id = c.season_id
+			JOIN series se ON se.id = s.series_id
+			WHERE (se.monetization_enabled OR se.watermark <> 'none')
+			AND (ci.image_url = ? OR ci.thumbnail_url = ? OR ci.variants @> ?::jsonb OR ci.tiles @> ?::jsonb)
+		) AND NOT EXISTS (
+			SELECT 1 FROM series
+			WHERE thumbnail_url = ? OR cover_image_url = ? OR banner_image_url = ?
+		)`, key, key, string(element), string(element), key, key, key).
+		Scan(&premium).Error
+	return premium, err
+}
+
+func (r *comicRepository) HasProtectedSeries() (bool, error) {
+	var protected bool
+	err := r.db.Raw("SELECT EXISTS (SELECT 1 FROM series WHERE monetization_enabled OR watermark <> 'none')").
+		Scan(&protected).Error
+	return protected, err
+}
+
+// ensurePageKeyIndexes indexes the columns IsProtectedPageKey looks keys up
+// in, since that lookup runs for every request to /uploads.
+func ensurePageKeyIndexes(db *gorm.DB) {
+	statements := []string{
+		`CREATE INDEX IF NOT EXISTS idx_chapter_images_image_url ON chapter_images (image_url)`,
+		`CREATE INDEX IF NOT EXISTS idx_chapter_images_thumbnail_url ON chapter_images (thumbnail_url)`,
+		`CREATE INDEX IF NOT EXISTS idx_chapter_images_variants ON chapter_images USING GIN (variants jsonb_path_ops)`,
+		`CREATE INDEX IF NOT EXISTS idx_chapter_images_tiles ON chapter_images USING GIN (tiles jsonb_path_ops)`,
+		`CREATE INDEX IF NOT EXISTS idx_series_thumbnail_url ON series (thumbnail_url)`,
+		`CREATE INDEX IF NOT EXISTS idx_series_cover_image_url ON series (cover_image_url)`,
+		`CREATE INDEX IF NOT EXISTS idx_series_banner_image_url ON series (banner_image_url)`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			log.Println("Failed to create page key index, serving uploads will be slower: ", err)
+		}
+	}
+}
+
+// lockChapter takes a row lock on the chapter so concurrent page edits are
+// serialised and Order stays contiguous.
+func lockChapter(tx *gorm.DB, chapterID uuid.UUID) error {
+	var chapter domain.Chapter
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&chapter, chapterID).Error
+}
+
+func sortImagesByOrder(images []domain.ChapterImage) {
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Order < images[j].Order
+	})
+}
+
+// deleteChapterContent removes the pages, text layers and translations that
+// belong to the given chapters. chapterIDs may be a slice or a subquery.
+func deleteChapterContent(tx *gorm.DB, chapterIDs interface{}) error {
+	imageIDs := tx.Model(&domain.ChapterImage{}).Select("id").Where("chapter_id IN (?)", chapterIDs)
+	layerIDs := tx.Model(&domain.TextLayer{}).Select("id").Where("chapter_image_id IN (?)", imageIDs)
+
+	if err := tx.Where("text_layer_id IN (?)", layerIDs).Delete(&domain.Translation{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("chapter_image_id IN (?)", imageIDs).Delete(&domain.TextLayer{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("chapter_id IN (?)", chapterIDs).Delete(&domain.ChapterImage{}).Error
+}
+
+func (r *comicRepository) ListSeriesCollaborators(seriesID uuid.UUID) ([]domain.SeriesCollaborator, error) {
+	var collaborators []domain.SeriesCollaborator
+	err := r.db.Where("series_id = ?", seriesID).Order("created_at asc").Find(&collaborators).Error
+	if err != nil {
+		return nil, err
+	}
+	return collaborators, nil
+}
+
+func (r *comicRepository) IsSeriesCollaborator(seriesID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.SeriesCollaborator{}).
+		Where("series_id = ? AND user_id = ?", seriesID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *comicRepository) AddSeriesCollaborator(collaborator *domain.SeriesCollaborator) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(collaborator).Error
+}
+
+func (r *comicRepository) RemoveSeriesCollaborator(seriesID, userID uuid.UUID) error {
+	result := r.db.Where("series_id = ? AND user_id = ?", seriesID, userID).Delete(&domain.SeriesCollaborator{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
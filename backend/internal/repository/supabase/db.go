@@ -22,6 +22,7 @@ func NewDB() *gorm.DB {
 	err = db.AutoMigrate(
 		&domain.User{},
 		&domain.Series{},
+		&domain.SeriesCollaborator{},
 		&domain.Season{},
 		&domain.Chapter{},
 		&domain.ChapterImage{},
@@ -29,10 +30,17 @@ func NewDB() *gorm.DB {
 		&domain.Translation{},
 		&domain.Tag{},
 		&domain.TagTranslation{},
+		&domain.TagAlias{},
+		&domain.UploadSession{},
+		&domain.UploadChunk{},
+		&domain.Blob{},
 	)
 	if err != nil {
 		log.Fatal("Failed to migrate database: ", err)
 	}
+	ensureSearchIndexes(db)
+	ensureBlobIndexes(db)
+	ensurePageKeyIndexes(db)
 
 	return db
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/pur108/ebook-platform/backend/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type layerRepository struct {
@@ -18,6 +19,14 @@ func (r *layerRepository) CreateLayer(layer *domain.TextLayer) error {
 	return r.db.Create(layer).Error
 }
 
+func (r *layerRepository) GetLayerByID(id uuid.UUID) (*domain.TextLayer, error) {
+	var layer domain.TextLayer
+	if err := r.db.Preload("Translations").First(&layer, id).Error; err != nil {
+		return nil, err
+	}
+	return &layer, nil
+}
+
 func (r *layerRepository) GetLayersByImageID(imageID uuid.UUID) ([]domain.TextLayer, error) {
 	var layers []domain.TextLayer
 	err := r.db.Where("chapter_image_id = ?", imageID).Preload("Translations").Find(&layers).Error
@@ -27,6 +36,74 @@ func (r *layerRepository) GetLayersByImageID(imageID uuid.UUID) ([]domain.TextLa
 	return layers, nil
 }
 
+func (r *layerRepository) UpdateLayer(layer *domain.TextLayer) error {
+	return r.db.Omit(clause.Associations).Save(layer).Error
+}
+
+func (r *layerRepository) DeleteLayer(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("text_layer_id = ?", id).Delete(&domain.Translation{}).Error; err != nil {
+			return err
+		}
+		res := tx.Delete(&domain.TextLayer{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *layerRepository) ReplaceLayers(imageID uuid.UUID, layers []domain.TextLayer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the page so concurrent saves from two editor tabs apply one
+		// after the other instead of interleaving.
+		var image domain.ChapterImage
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&image, imageID).Error; err != nil {
+			return err
+		}
+
+		var existingIDs []uuid.UUID
+		if err := tx.Model(&domain.TextLayer{}).Where("chapter_image_id = ?", imageID).Pluck("id", &existingIDs).Error; err != nil {
+			return err
+		}
+		existing := make(map[uuid.UUID]bool, len(existingIDs))
+		for _, id := range existingIDs {
+			existing[id] = true
+		}
+
+		for i := range layers {
+			layer := &layers[i]
+			layer.ChapterImageID = imageID
+			if existing[layer.ID] {
+				delete(existing, layer.ID)
+				if err := tx.Omit(clause.Associations).Save(layer).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			layer.ID = uuid.New()
+			if err := tx.Omit(clause.Associations).Create(layer).Error; err != nil {
+				return err
+			}
+		}
+
+		var removed []uuid.UUID
+		for id := range existing {
+			removed = append(removed, id)
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+		if err := tx.Where("text_layer_id IN ?", removed).Delete(&domain.Translation{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", removed).Delete(&domain.TextLayer{}).Error
+	})
+}
+
 func (r *layerRepository) CreateTranslation(translation *domain.Translation) error {
 	return r.db.Create(translation).Error
 }
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// seriesSearchDocument concatenates every searchable series column. It is
+// used verbatim in both the index definitions and the queries so Postgres
+// can use the indexes.
+const seriesSearchDocument = `(coalesce(series.title->>'en', '') || ' ' || coalesce(series.title->>'th', '') || ' ' ||
+	coalesce(series.subtitle->>'en', '') || ' ' || coalesce(series.subtitle->>'th', '') || ' ' ||
+	coalesce(series.description->>'en', '') || ' ' || coalesce(series.description->>'th', '') || ' ' ||
+	coalesce(series.author, ''))`
+
+// ensureSearchIndexes creates the full-text (English stemming) and trigram
+// indexes used by search. Trigram indexes back the substring matching used
+// for Thai; they only help if the database's LC_CTYPE classifies Thai
+// characters as letters (any UTF-8 locale other than C does).
+func ensureSearchIndexes(db *gorm.DB) {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+		`CREATE INDEX IF NOT EXISTS idx_series_search_fts ON series USING GIN (to_tsvector('english', ` + seriesSearchDocument + `))`,
+		`CREATE INDEX IF NOT EXISTS idx_series_search_trgm ON series USING GIN (` + seriesSearchDocument + ` gin_trgm_ops)`,
+		`CREATE INDEX IF NOT EXISTS idx_tag_translations_name_trgm ON tag_translations USING GIN (name gin_trgm_ops)`,
+		`CREATE INDEX IF NOT EXISTS idx_text_layers_search_fts ON text_layers USING GIN (to_tsvector('english', original_text))`,
+		`CREATE INDEX IF NOT EXISTS idx_text_layers_search_trgm ON text_layers USING GIN (original_text gin_trgm_ops)`,
+		`CREATE INDEX IF NOT EXISTS idx_translations_search_fts ON translations USING GIN (to_tsvector('english', translated_text))`,
+		`CREATE INDEX IF NOT EXISTS idx_translations_search_trgm ON translations USING GIN (translated_text gin_trgm_ops)`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			log.Println("Failed to create search index, search will be slower and ranked without trigram similarity: ", err)
+		}
+	}
+}
+
+type searchRepository struct {
+	db *gorm.DB
+	// trigram is whether pg_trgm is installed. Without it, similarity()
+	// doesn't exist and results are ranked on full-text matches alone.
+	trigram bool
+}
+
+func NewSearchRepository(db *gorm.DB) domain.SearchRepository {
+	var trigram bool
+	if err := db.Raw("SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')").Scan(&trigram).Error; err != nil {
+		log.Println("Failed to check for pg_trgm, ranking search without it: ", err)
+	}
+	return &searchRepository{db, trigram}
+}
+
+func (r *searchRepository) SearchSeries(query domain.SearchQuery) ([]domain.Series, error) {
+	db := r.db.Preload("Tags.Translations")
+	if len(query.Statuses) > 0 {
+		db = db.Where("series.status IN ?", query.Statuses)
+	}
+	if len(query.Visibilities) > 0 {
+		db = db.Where("series.visibility IN ?", query.Visibilities)
+	}
+	if query.NSFW != nil {
+		db = db.Where("series.nsfw = ?", *query.NSFW)
+	}
+
+	// A series matches on stemmed English words, or if every term appears
+	// in its text or in one of its tag names.
+	fts := fmt.Sprintf("to_tsvector('english', %s) @@ plainto_tsquery('english', ?)", seriesSearchDocument)
+	var termConds []string
+	args := []interface{}{query.Text}
+	for _, term := range query.Terms {
+		pattern := likePattern(term)
+		termConds = append(termConds, fmt.Sprintf(`(%s ILIKE ? OR EXISTS (
+			SELECT 1 FROM series_tags st JOIN tag_translations tt ON tt.tag_id = st.tag_id
+			WHERE st.series_id = series.id AND tt.name ILIKE ?))`, seriesSearchDocument))
+		args = append(args, pattern, pattern)
+	}
+	cond := fts
+	if len(termConds) > 0 {
+		cond = fmt.Sprintf("(%s OR (%s))", fts, strings.Join(termConds, " AND "))
+	}
+	db = db.Where(cond, args...)
+
+	rank := fmt.Sprintf("ts_rank(to_tsvector('english', %s), plainto_tsquery('english', ?))", seriesSearchDocument)
+	rankArgs := []interface{}{query.Text}
+	if r.trigram {
+		rank += fmt.Sprintf(" + similarity(%s, ?)", seriesSearchDocument)
+		rankArgs = append(rankArgs, query.Text)
+	}
+	db = db.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                rank + " DESC",
+		Vars:               rankArgs,
+		WithoutParentheses: true,
+	}})
+
+	var series []domain.Series
+	if err := db.Limit(query.Limit).Find(&series).Error; err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (r *searchRepository) SearchDialogue(query domain.SearchQuery) ([]domain.DialogueMatch, error) {
+	var policy []string
+	var policyArgs []interface{}
+	if len(query.Statuses) > 0 {
+		policy = append(policy, "s.status IN ?")
+		policyArgs = append(policyArgs, query.Statuses)
+	}
+	if len(query.Visibilities) > 0 {
+		policy = append(policy, "s.visibility IN ?")
+		policyArgs = append(policyArgs, query.Visibilities)
+	}
+	if query.NSFW != nil {
+		policy = append(policy, "s.nsfw = ?")
+		policyArgs = append(policyArgs, *query.NSFW)
+	}
+	policy = append(policy, "c.status = ?")
+	policyArgs = append(policyArgs, domain.ChapterPublished)
+
+	match := func(column string) (string, []interface{}) {
+		conds := []string{fmt.Sprintf("to_tsvector('english', %s) @@ plainto_tsquery('english', ?)", column)}
+		args := []interface{}{query.Text}
+		var termConds []string
+		for _, term := range query.Terms {
+			termConds = append(termConds, column+" ILIKE ?")
+			args = append(args, likePattern(term))
+		}
+		if len(termConds) > 0 {
+			conds = append(conds, "("+strings.Join(termConds, " AND ")+")")
+		}
+		return "(" + strings.Join(conds, " OR ") + ")", args
+	}
+	originalMatch, originalArgs := match("tl.original_text")
+	translatedMatch, translatedArgs := match("tr.translated_text")
+
+	joins := `
+		JOIN chapter_images ci ON ci.id = tl.chapter_image_id
+		JOIN chapters c ON c.id = ci.chapter_id
+		JOIN seasons se ON se.id = c.season_id
+		JOIN series s ON s.id = se.series_id`
+	where := strings.Join(policy, " AND ")
+
+	sql := `
+		SELECT * FROM (
+			SELECT s.id AS series_id, c.id AS chapter_id, ci.id AS chapter_image_id,
+				tl.id AS text_layer_id, tl.original_text AS text, '' AS language_code
+			FROM text_layers tl` + joins + `
+			WHERE ` + where + ` AND ` + originalMatch + `
+			UNION ALL
+			SELECT s.id, c.id, ci.id, tl.id, tr.translated_text, tr.language_code
+			FROM translations tr
+			JOIN text_layers tl ON tl.id = tr.text_layer_id` + joins + `
+			WHERE ` + where + ` AND ` + translatedMatch + `
+		) matches
+		LIMIT ?`
+
+	var args []interface{}
+	args = append(args, policyArgs...)
+	args = append(args, originalArgs...)
+	args = append(args, policyArgs...)
+	args = append(args, translatedArgs...)
+	args = append(args, query.Limit)
+
+	var matches []domain.DialogueMatch
+	if err := r.db.Raw(sql, args...).Scan(&matches).Error; err != nil {
+		return nil, err
+	}
+	return matches, nil
+}
+
+// likePattern wraps term for a substring ILIKE, escaping wildcards.
+func likePattern(term string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+	return "%" + escaped + "%"
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) domain.TagRepository {
+	return &tagRepository{db}
+}
+
+func (r *tagRepository) CreateTag(tag *domain.Tag) error {
+	return r.db.Create(tag).Error
+}
+
+func (r *tagRepository) GetTagByID(id uuid.UUID) (*domain.Tag, error) {
+	var tag domain.Tag
+	err := r.db.Preload("Translations").First(&tag, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) FindTagBySlug(slug string) (*domain.Tag, error) {
+	var tag domain.Tag
+	err := r.db.Preload("Translations").Where("slug = ?", slug).First(&tag).Error
+	if err == nil {
+		return &tag, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var alias domain.TagAlias
+	if err := r.db.Where("slug = ?", slug).First(&alias).Error; err != nil {
+		return nil, err
+	}
+	return r.GetTagByID(alias.TagID)
+}
+
+func (r *tagRepository) ListTags() ([]domain.Tag, error) {
+	var tags []domain.Tag
+	err := r.db.Preload("Translations").Order("slug asc").Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) AddTranslation(translation *domain.TagTranslation) error {
+	return r.db.Create(translation).Error
+}
+
+func (r *tagRepository) CreateAlias(alias *domain.TagAlias) error {
+	return r.db.Create(alias).Error
+}
+
+func (r *tagRepository) MergeTags(sourceID, targetID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var source domain.Tag
+		if err := tx.First(&source, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&domain.Tag{}, targetID).Error; err != nil {
+			return err
+		}
+
+		// Series tagged with both keep a single link to the target.
+		err := tx.Exec(`
+			INSERT INTO series_tags (series_id, tag_id)
+			SELECT series_id, ? FROM series_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, targetID, sourceID).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM series_tags WHERE tag_id = ?", sourceID).Error; err != nil {
+			return err
+		}
+
+		err = tx.Model(&domain.TagAlias{}).Where("tag_id = ?", sourceID).Update("tag_id", targetID).Error
+		if err != nil {
+			return err
+		}
+		// Names in languages the target lacks move over; the rest go.
+		err = tx.Model(&domain.TagTranslation{}).
+			Where("tag_id = ? AND language NOT IN (?)", sourceID,
+				tx.Model(&domain.TagTranslation{}).Select("language").Where("tag_id = ?", targetID)).
+			Update("tag_id", targetID).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", sourceID).Delete(&domain.TagTranslation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&source).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&domain.TagAlias{
+			ID:        uuid.New(),
+			TagID:     targetID,
+			Slug:      source.Slug,
+			CreatedAt: time.Now(),
+		}).Error
+	})
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type uploadSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewUploadSessionRepository(db *gorm.DB) domain.UploadSessionRepository {
+	return &uploadSessionRepository{db}
+}
+
+func (r *uploadSessionRepository) CreateSession(session *domain.UploadSession) error {
+	return r.db.Create(session).Error
+}
+
+func (r *uploadSessionRepository) GetSession(id uuid.UUID) (*domain.UploadSession, error) {
+	var session domain.UploadSession
+	err := r.db.Preload("Chunks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("byte_offset")
+	}).First(&session, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// AppendChunk advances the offset with a compare-and-set, so of two chunks
+// racing for the same offset exactly one is recorded.
+func (r *uploadSessionRepository) AppendChunk(chunk *domain.UploadChunk, expiresAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.UploadSession{}).
+			Where("id = ? AND upload_offset = ?", chunk.SessionID, chunk.Offset).
+			Updates(map[string]interface{}{
+				"upload_offset": chunk.Offset + chunk.Size,
+				"expires_at":    expiresAt,
+				"updated_at":    time.Now(),
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.ErrStaleUploadOffset
+		}
+		return tx.Create(chunk).Error
+	})
+}
+
+func (r *uploadSessionRepository) DeleteSession(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("session_id = ?", id).Delete(&domain.UploadChunk{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.UploadSession{}, id).Error
+	})
+}
+
+func (r *uploadSessionRepository) ListExpiredSessions(now time.Time, limit int) ([]domain.UploadSession, error) {
+	var sessions []domain.UploadSession
+	err := r.db.Preload("Chunks").
+		Where("expires_at < ?", now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&sessions).Error
+	return sessions, err
+}
@@ -0,0 +1,67 @@
+// Package storage provides the domain.FileStorage drivers.
+package storage
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+)
+
+// NewStorage builds the driver selected by STORAGE_DRIVER ("local", the
+// default, or "s3") from the environment.
+//
+// local: UPLOAD_DIR (default ./uploads) and UPLOAD_PUBLIC_URL (default
+// http://localhost:8080/uploads, which the server serves from UPLOAD_DIR).
+//
+// s3: S3_ENDPOINT, S3_REGION (default us-east-1), S3_BUCKET,
+// S3_ACCESS_KEY_ID, S3_SECRET_ACCESS_KEY and S3_PUBLIC_URL (default
+// <endpoint>/<bucket>). Any S3-compatible service works, including MinIO.
+func NewStorage() domain.FileStorage {
+	switch driver := getenv("STORAGE_DRIVER", "local"); driver {
+	case "local":
+		return NewLocalStorage(
+			getenv("UPLOAD_DIR", "./uploads"),
+			getenv("UPLOAD_PUBLIC_URL", "http://localhost:8080/uploads"),
+		)
+	case "s3":
+		s, err := NewS3Storage(S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          getenv("S3_REGION", "us-east-1"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+		})
+		if err != nil {
+			log.Fatal("Failed to configure S3 storage: ", err)
+		}
+		return s
+	default:
+		log.Fatalf("Unknown STORAGE_DRIVER %q", driver)
+		return nil
+	}
+}
+
+func getenv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// cleanKey rejects keys that are empty or would escape the storage root.
+func cleanKey(key string) (string, error) {
+	clean := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if clean == "" || clean != key {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return clean, nil
+}
+
+func joinURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
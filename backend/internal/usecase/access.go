@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotFound     = errors.New("resource not found")
+	ErrForbidden    = errors.New("you do not have permission to modify this resource")
+	ErrConflict     = errors.New("resource already exists")
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrAgeGateRequired means the content is NSFW and the viewer has not
+	// confirmed their age and opted in.
+	ErrAgeGateRequired = errors.New("this series is for mature readers; confirm your age and enable NSFW content to continue")
+)
+
+// Requester is the user performing an action. On public routes the zero
+// value stands for an anonymous visitor.
+type Requester struct {
+	UserID uuid.UUID
+	Role   domain.UserRole
+}
+
+func (r Requester) IsAdmin() bool {
+	return r.Role == domain.RoleAdmin
+}
+
+// CanManage reports whether the requester owns the series or is an admin.
+func (r Requester) CanManage(series *domain.Series) bool {
+	return r.IsAdmin() || series.CreatorID == r.UserID
+}
+
+// CanView reports whether the requester may open the series by direct link.
+// Drafts and private series are visible only to their owner and admins;
+// everything else, including unlisted and archived series, is reachable.
+func (r Requester) CanView(series *domain.Series) bool {
+	if r.CanManage(series) {
+		return true
+	}
+	return series.Status != domain.SeriesDraft && series.Visibility != domain.VisibilityPrivate
+}
+
+// CanRead reports whether the requester may read the chapter, given that
+// they can already view its series.
+func (r Requester) CanRead(series *domain.Series, chapter *domain.Chapter) bool {
+	return r.CanManage(series) || chapter.Status == domain.ChapterPublished
+}
+
+// viewerAllowsNSFW reports whether the viewer has confirmed their age and
+// opted in to NSFW content. Anonymous visitors never have.
+func viewerAllowsNSFW(userRepo domain.UserRepository, viewer Requester) bool {
+	if viewer.UserID == uuid.Nil {
+		return false
+	}
+	user, err := userRepo.FindByID(viewer.UserID)
+	if err != nil {
+		return false
+	}
+	return user.CanViewNSFW()
+}
+
+// notFound maps GORM's missing-record error to ErrNotFound so handlers
+// don't need to know about the persistence layer.
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func authorizeSeries(repo domain.ComicRepository, req Requester, seriesID uuid.UUID) (*domain.Series, error) {
+	series, err := repo.GetSeriesByID(seriesID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if !req.CanManage(series) {
+		return nil, ErrForbidden
+	}
+	return series, nil
+}
+
+func authorizeSeason(repo domain.ComicRepository, req Requester, seasonID uuid.UUID) (*domain.Season, error) {
+	season, err := repo.GetSeasonByID(seasonID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if _, err := authorizeSeries(repo, req, season.SeriesID); err != nil {
+		return nil, err
+	}
+	return season, nil
+}
+
+func authorizeChapter(repo domain.ComicRepository, req Requester, chapterID uuid.UUID) (*domain.Chapter, error) {
+	chapter, err := repo.GetChapterByID(chapterID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if _, err := authorizeSeason(repo, req, chapter.SeasonID); err != nil {
+		return nil, err
+	}
+	return chapter, nil
+}
+
+func authorizeChapterImage(repo domain.ComicRepository, req Requester, imageID uuid.UUID) (*domain.ChapterImage, error) {
+	image, err := repo.GetChapterImageByID(imageID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if _, err := authorizeChapter(repo, req, image.ChapterID); err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
+// authorizeImageLayers checks that the requester may edit the text layers
+// and translations on a page: its series' owner, an admin, or one of the
+// series' collaborators.
+func authorizeImageLayers(repo domain.ComicRepository, req Requester, imageID uuid.UUID) error {
+	series, err := repo.GetSeriesByChapterImageID(imageID)
+	if err != nil {
+		return notFound(err)
+	}
+	if req.CanManage(series) {
+		return nil
+	}
+	collaborator, err := repo.IsSeriesCollaborator(series.ID, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !collaborator {
+		return ErrForbidden
+	}
+	return nil
+}
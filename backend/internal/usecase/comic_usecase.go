@@ -1,6 +1,10 @@
 package usecase
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,17 +13,46 @@ import (
 
 type ComicUsecase interface {
 	CreateSeries(input CreateSeriesInput) (*domain.Series, error)
-	GetSeries(id uuid.UUID) (*domain.Series, error)
-	GetChapter(id uuid.UUID) (*domain.Chapter, error)
-	ListSeries() ([]domain.Series, error)
+	GetSeries(viewer Requester, id uuid.UUID) (*domain.Series, error)
+	GetChapter(viewer Requester, id uuid.UUID) (*domain.ChapterView, error)
+	ListSeries(viewer Requester, input ListSeriesInput) (*domain.SeriesPage, error)
+	ListCreatorSeries(req Requester) ([]domain.CreatorSeriesSummary, error)
+	UpdateSeries(req Requester, id uuid.UUID, input UpdateSeriesInput) (*domain.Series, error)
+	ArchiveSeries(req Requester, id uuid.UUID) (*domain.Series, error)
+	DeleteSeries(req Requester, id uuid.UUID) error
+	PublishScheduled(now time.Time) (seriesCount, chapterCount int64, err error)
+
+	// Collaborators may edit the text layers and translations of a series'
+	// pages. Only the owner and admins manage them.
+	ListCollaborators(req Requester, seriesID uuid.UUID) ([]domain.SeriesCollaborator, error)
+	AddCollaborator(req Requester, seriesID, userID uuid.UUID) (*domain.SeriesCollaborator, error)
+	RemoveCollaborator(req Requester, seriesID, userID uuid.UUID) error
+
+	ListSeasons(req Requester, seriesID uuid.UUID) ([]domain.Season, error)
+	CreateSeason(req Requester, seriesID uuid.UUID, input SeasonInput) (*domain.Season, error)
+	UpdateSeason(req Requester, seasonID uuid.UUID, input UpdateSeasonInput) (*domain.Season, error)
+	DeleteSeason(req Requester, seasonID uuid.UUID) error
+
+	CreateChapter(req Requester, seasonID uuid.UUID, input ChapterInput) (*domain.Chapter, error)
+	UpdateChapter(req Requester, chapterID uuid.UUID, input UpdateChapterInput) (*domain.Chapter, error)
+	DeleteChapter(req Requester, chapterID uuid.UUID) error
+
+	AddChapterPages(req Requester, chapterID uuid.UUID, files []FileUpload) ([]domain.ChapterImage, error)
+	ReorderChapterPages(req Requester, chapterID uuid.UUID, imageIDs []uuid.UUID) ([]domain.ChapterImage, error)
+	ReplaceChapterPage(req Requester, imageID uuid.UUID, file FileUpload) (*domain.ChapterImage, error)
+	DeleteChapterPage(req Requester, imageID uuid.UUID) error
 }
 
 type comicUsecase struct {
-	comicRepo domain.ComicRepository
+	comicRepo     domain.ComicRepository
+	userRepo      domain.UserRepository
+	tagUsecase    TagUsecase
+	uploadUsecase UploadUsecase
+	mediaUsecase  MediaUsecase
 }
 
-func NewComicUsecase(comicRepo domain.ComicRepository) ComicUsecase {
-	return &comicUsecase{comicRepo}
+func NewComicUsecase(comicRepo domain.ComicRepository, userRepo domain.UserRepository, tagUsecase TagUsecase, uploadUsecase UploadUsecase, mediaUsecase MediaUsecase) ComicUsecase {
+	return &comicUsecase{comicRepo, userRepo, tagUsecase, uploadUsecase, mediaUsecase}
 }
 
 type CreateSeriesInput struct {
@@ -30,9 +63,9 @@ type CreateSeriesInput struct {
 	Author              string
 	Genres              []string
 	Tags                []domain.MultilingualText
-	ThumbnailURL        string
-	CoverImageURL       string
-	BannerImageURL      string
+	ThumbnailURL        domain.AssetKey
+	CoverImageURL       domain.AssetKey
+	BannerImageURL      domain.AssetKey
 	Status              domain.SeriesStatus
 	Visibility          string
 	NSFW                bool
@@ -40,9 +73,27 @@ type CreateSeriesInput struct {
 	MonetizationEnabled bool
 	MonetizationType    string
 	DefaultUnlockType   string
+	Watermark           domain.WatermarkMode
 }
 
 func (u *comicUsecase) CreateSeries(input CreateSeriesInput) (*domain.Series, error) {
+	if input.Watermark == "" {
+		input.Watermark = domain.WatermarkNone
+	}
+	if err := validateWatermark(input.Watermark); err != nil {
+		return nil, err
+	}
+	// Unset status and visibility take the column defaults.
+	if input.Status != "" {
+		if err := validateSeriesStatus(input.Status); err != nil {
+			return nil, err
+		}
+	}
+	if input.Visibility != "" {
+		if err := validateVisibility(input.Visibility); err != nil {
+			return nil, err
+		}
+	}
 	series := &domain.Series{
 		ID:          uuid.New(),
 		CreatorID:   input.CreatorID,
@@ -62,36 +113,14 @@ func (u *comicUsecase) CreateSeries(input CreateSeriesInput) (*domain.Series, er
 		MonetizationEnabled: input.MonetizationEnabled,
 		MonetizationType:    input.MonetizationType,
 		DefaultUnlockType:   input.DefaultUnlockType,
+		Watermark:           input.Watermark,
 		CreatedAt:           time.Now(),
 		UpdatedAt:           time.Now(),
 	}
 
-	// Process Tags
-	var tags []domain.Tag
-	for _, t := range input.Tags {
-		tagID := uuid.New()
-		slug := t.En // Simple slug for now, ideally use a slug generator
-
-		tags = append(tags, domain.Tag{
-			ID:        tagID,
-			Slug:      slug,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-			Translations: []domain.TagTranslation{
-				{
-					ID:       uuid.New(),
-					TagID:    tagID,
-					Language: "en",
-					Name:     t.En,
-				},
-				{
-					ID:       uuid.New(),
-					TagID:    tagID,
-					Language: "th",
-					Name:     t.Th,
-				},
-			},
-		})
+	tags, err := u.tagUsecase.ResolveTags(input.Tags)
+	if err != nil {
+		return nil, err
 	}
 	series.Tags = tags
 
@@ -102,14 +131,814 @@ func (u *comicUsecase) CreateSeries(input CreateSeriesInput) (*domain.Series, er
 	return series, nil
 }
 
-func (u *comicUsecase) GetSeries(id uuid.UUID) (*domain.Series, error) {
-	return u.comicRepo.GetSeriesByID(id)
+// GetSeries returns a series if the viewer may see it. Readers only get the
+// published chapters; owners and admins get everything. Series the viewer may
+// not see are reported as ErrNotFound so their existence isn't leaked.
+func (u *comicUsecase) GetSeries(viewer Requester, id uuid.UUID) (*domain.Series, error) {
+	series, err := u.comicRepo.GetSeriesByID(id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if !viewer.CanView(series) {
+		return nil, ErrNotFound
+	}
+	if err := u.checkAgeGate(viewer, series); err != nil {
+		return nil, err
+	}
+
+	if !viewer.CanManage(series) {
+		for i := range series.Seasons {
+			season := &series.Seasons[i]
+			published := season.Chapters[:0]
+			for _, c := range season.Chapters {
+				if c.Status == domain.ChapterPublished {
+					published = append(published, c)
+				}
+			}
+			season.Chapters = published
+		}
+	}
+	return series, nil
+}
+
+// GetChapter returns a chapter for the reader together with its series and
+// season summaries and the previous and next published chapters. Pages of
+// monetized and watermarked series are served through short-lived signed
+// URLs; in watermarked series those carry the reader's ID to mark pages
+// with, so readers must sign in to read them.
+func (u *comicUsecase) GetChapter(viewer Requester, id uuid.UUID) (*domain.ChapterView, error) {
+	chapter, err := u.comicRepo.GetChapterByID(id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	season, err := u.comicRepo.GetSeasonByID(chapter.SeasonID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	series, err := u.comicRepo.GetSeriesByID(season.SeriesID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if !viewer.CanView(series) || !viewer.CanRead(series, chapter) {
+		return nil, ErrNotFound
+	}
+	if err := u.checkAgeGate(viewer, series); err != nil {
+		return nil, err
+	}
+	if series.Watermark.Marks() && viewer.UserID == uuid.Nil {
+		return nil, fmt.Errorf("%w: sign in to read this series", ErrForbidden)
+	}
+
+	if !viewer.CanManage(series) {
+		// A failed counter update shouldn't stop anyone reading.
+		_ = u.comicRepo.IncrementSeriesViews(series.ID)
+	}
+	if series.MonetizationEnabled || series.Watermark.Marks() {
+		mark := series.Watermark
+		if viewer.CanManage(series) {
+			mark = domain.WatermarkNone
+		}
+		signChapterImages(u.mediaUsecase, viewer, mark, chapter.Images)
+	}
+
+	refs, err := u.comicRepo.ListPublishedChapterRefs(series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &domain.ChapterView{
+		Chapter: chapter,
+		Series: domain.SeriesSummary{
+			ID:           series.ID,
+			CreatorID:    series.CreatorID,
+			Title:        series.Title,
+			Author:       series.Author,
+			ThumbnailURL: series.ThumbnailURL,
+		},
+		Season: domain.SeasonSummary{
+			ID:           season.ID,
+			SeasonNumber: season.SeasonNumber,
+			Title:        season.Title,
+		},
+	}
+
+	// The current chapter may be an unpublished preview, so neighbours are
+	// found by position rather than by index in refs.
+	current := domain.ChapterRef{ID: chapter.ID, SeasonNumber: season.SeasonNumber, ChapterNumber: chapter.ChapterNumber}
+	for i := range refs {
+		if refs[i].Before(current) {
+			view.PrevChapterID = &refs[i].ID
+		} else if current.Before(refs[i]) && view.NextChapterID == nil {
+			view.NextChapterID = &refs[i].ID
+		}
+	}
+	return view, nil
+}
+
+// ListSeriesInput holds the catalogue filters a reader can choose.
+type ListSeriesInput struct {
+	Genre       string
+	TagSlug     string
+	Statuses    []domain.SeriesStatus
+	CreatorID   *uuid.UUID
+	NSFW        *bool
+	AvailableIn string
+	Sort        domain.SeriesSort
+	Locale      string
+	Cursor      string
+	Limit       int
+}
+
+// listableStatuses are the statuses that appear in the public catalogue.
+var listableStatuses = []domain.SeriesStatus{domain.SeriesPublished, domain.SeriesHiatus, domain.SeriesCompleted}
+
+// ListSeries returns a page of the public catalogue: published, on-hiatus
+// and completed series with public visibility. Unlisted, private, draft and
+// archived series never appear here, not even for their owner. NSFW series
+// are left out unless the viewer has confirmed their age and opted in.
+func (u *comicUsecase) ListSeries(viewer Requester, input ListSeriesInput) (*domain.SeriesPage, error) {
+	query := domain.SeriesQuery{
+		Statuses:     listableStatuses,
+		Visibilities: []string{domain.VisibilityPublic},
+		Genre:        input.Genre,
+		TagSlug:      input.TagSlug,
+		CreatorID:    input.CreatorID,
+		AvailableIn:  input.AvailableIn,
+		Sort:         input.Sort,
+		Locale:       input.Locale,
+		Limit:        input.Limit,
+	}
+
+	if len(input.Statuses) > 0 {
+		query.Statuses = nil
+		for _, status := range input.Statuses {
+			for _, listable := range listableStatuses {
+				if status == listable {
+					query.Statuses = append(query.Statuses, status)
+				}
+			}
+		}
+		if len(query.Statuses) == 0 {
+			return &domain.SeriesPage{Items: []domain.Series{}}, nil
+		}
+	}
+
+	safeOnly := false
+	query.NSFW = input.NSFW
+	if !u.viewerAllowsNSFW(viewer) {
+		if input.NSFW != nil && *input.NSFW {
+			return &domain.SeriesPage{Items: []domain.Series{}}, nil
+		}
+		query.NSFW = &safeOnly
+	}
+
+	switch query.Sort {
+	case "":
+		query.Sort = domain.SortUpdated
+	case domain.SortNewest, domain.SortUpdated, domain.SortPopular, domain.SortTitle:
+	default:
+		return nil, fmt.Errorf("%w: unknown sort %q", ErrInvalidInput, query.Sort)
+	}
+	if query.Limit <= 0 {
+		query.Limit = domain.DefaultSeriesPageSize
+	}
+	if query.Limit > domain.MaxSeriesPageSize {
+		query.Limit = domain.MaxSeriesPageSize
+	}
+
+	if input.Cursor != "" {
+		cursor, err := decodeSeriesCursor(input.Cursor, query.Sort)
+		if err != nil {
+			return nil, err
+		}
+		query.Cursor = cursor
+	}
+
+	// Fetch one extra row to learn whether there is a next page.
+	query.Limit++
+	series, err := u.comicRepo.ListSeries(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.SeriesPage{Items: series}
+	if len(series) == query.Limit {
+		page.Items = series[:len(series)-1]
+		page.NextCursor = encodeSeriesCursor(page.Items[len(page.Items)-1], query)
+	}
+	if page.Items == nil {
+		page.Items = []domain.Series{}
+	}
+	return page, nil
+}
+
+func encodeSeriesCursor(last domain.Series, query domain.SeriesQuery) string {
+	cursor := domain.SeriesCursor{ID: last.ID}
+	switch query.Sort {
+	case domain.SortNewest:
+		cursor.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	case domain.SortPopular:
+		cursor.Value = strconv.FormatInt(last.ViewCount, 10)
+	case domain.SortTitle:
+		cursor.Value = localizedTitle(last.Title, query.Locale)
+	default:
+		cursor.Value = last.UpdatedAt.Format(time.RFC3339Nano)
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeSeriesCursor parses an opaque cursor and checks that its value
+// matches the sort it is being used with.
+func decodeSeriesCursor(s string, sort domain.SeriesSort) (*domain.SeriesCursor, error) {
+	invalid := fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, invalid
+	}
+	var cursor domain.SeriesCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, invalid
+	}
+
+	switch sort {
+	case domain.SortPopular:
+		_, err = strconv.ParseInt(cursor.Value, 10, 64)
+	case domain.SortTitle:
+	default:
+		_, err = time.Parse(time.RFC3339Nano, cursor.Value)
+	}
+	if err != nil {
+		return nil, invalid
+	}
+	return &cursor, nil
+}
+
+// localizedTitle mirrors the title expression the repository sorts by:
+// the requested language, falling back to the other one.
+func localizedTitle(title domain.MultilingualText, locale string) string {
+	if locale == "th" {
+		if title.Th != "" {
+			return title.Th
+		}
+		return title.En
+	}
+	if title.En != "" {
+		return title.En
+	}
+	return title.Th
+}
+
+// checkAgeGate returns ErrAgeGateRequired if the series is NSFW and the
+// viewer is neither allowed to see NSFW content nor able to manage it.
+func (u *comicUsecase) checkAgeGate(viewer Requester, series *domain.Series) error {
+	if !series.NSFW || viewer.CanManage(series) || u.viewerAllowsNSFW(viewer) {
+		return nil
+	}
+	return ErrAgeGateRequired
+}
+
+func (u *comicUsecase) viewerAllowsNSFW(viewer Requester) bool {
+	return viewerAllowsNSFW(u.userRepo, viewer)
+}
+
+// ListCreatorSeries returns the requester's own series, including drafts,
+// private and unlisted ones.
+func (u *comicUsecase) ListCreatorSeries(req Requester) ([]domain.CreatorSeriesSummary, error) {
+	return u.comicRepo.ListSeriesByCreator(req.UserID)
+}
+
+// UpdateSeriesInput carries a partial series update; nil fields are left
+// unchanged.
+type UpdateSeriesInput struct {
+	Title               *domain.MultilingualText   `json:"title"`
+	Subtitle            *domain.MultilingualText   `json:"subtitle"`
+	Description         *domain.MultilingualText   `json:"description"`
+	Author              *string                    `json:"author"`
+	Genres              *[]string                  `json:"genres"`
+	Tags                *[]domain.MultilingualText `json:"tags"`
+	ThumbnailURL        *domain.AssetKey           `json:"thumbnail_url"`
+	CoverImageURL       *domain.AssetKey           `json:"cover_image_url"`
+	BannerImageURL      *domain.AssetKey           `json:"banner_image_url"`
+	Status              *domain.SeriesStatus       `json:"status"`
+	Visibility          *string                    `json:"visibility"`
+	NSFW                *bool                      `json:"nsfw"`
+	SchedulePublishAt   *time.Time                 `json:"schedule_publish_at"`
+	MonetizationEnabled *bool                      `json:"monetization_enabled"`
+	MonetizationType    *string                    `json:"monetization_type"`
+	DefaultUnlockType   *string                    `json:"default_unlock_type"`
+	Watermark           *domain.WatermarkMode      `json:"watermark"`
+}
+
+func (u *comicUsecase) UpdateSeries(req Requester, id uuid.UUID, input UpdateSeriesInput) (*domain.Series, error) {
+	series, err := authorizeSeries(u.comicRepo, req, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		if input.Title.En == "" && input.Title.Th == "" {
+			return nil, fmt.Errorf("%w: title must have at least one language", ErrInvalidInput)
+		}
+		series.Title = *input.Title
+	}
+	if input.Subtitle != nil {
+		series.Subtitle = *input.Subtitle
+	}
+	if input.Description != nil {
+		series.Description = *input.Description
+	}
+	if input.Author != nil {
+		series.Author = *input.Author
+	}
+	if input.Genres != nil {
+		series.Genres = *input.Genres
+	}
+	if input.Tags != nil {
+		tags, err := u.tagUsecase.ResolveTags(*input.Tags)
+		if err != nil {
+			return nil, err
+		}
+		series.Tags = tags
+	}
+	previousImages := seriesImageKeys(series)
+	if input.ThumbnailURL != nil {
+		series.ThumbnailURL = *input.ThumbnailURL
+	}
+	if input.CoverImageURL != nil {
+		series.CoverImageURL = *input.CoverImageURL
+	}
+	if input.BannerImageURL != nil {
+		series.BannerImageURL = *input.BannerImageURL
+	}
+	if input.Status != nil {
+		if err := validateSeriesStatus(*input.Status); err != nil {
+			return nil, err
+		}
+		series.Status = *input.Status
+	}
+	if input.Visibility != nil {
+		if err := validateVisibility(*input.Visibility); err != nil {
+			return nil, err
+		}
+		series.Visibility = *input.Visibility
+	}
+	if input.NSFW != nil {
+		series.NSFW = *input.NSFW
+	}
+	if input.SchedulePublishAt != nil {
+		series.SchedulePublishAt = input.SchedulePublishAt
+	}
+	if input.MonetizationEnabled != nil {
+		series.MonetizationEnabled = *input.MonetizationEnabled
+	}
+	if input.MonetizationType != nil {
+		series.MonetizationType = *input.MonetizationType
+	}
+	if input.DefaultUnlockType != nil {
+		series.DefaultUnlockType = *input.DefaultUnlockType
+	}
+	if input.Watermark != nil {
+		if err := validateWatermark(*input.Watermark); err != nil {
+			return nil, err
+		}
+		series.Watermark = *input.Watermark
+	}
+	series.UpdatedAt = time.Now()
+
+	if err := u.comicRepo.UpdateSeries(series); err != nil {
+		return nil, err
+	}
+	u.releaseImages(previousImages)
+	return series, nil
+}
+
+// ArchiveSeries hides a series from the catalogue without deleting it.
+func (u *comicUsecase) ArchiveSeries(req Requester, id uuid.UUID) (*domain.Series, error) {
+	status := domain.SeriesArchived
+	return u.UpdateSeries(req, id, UpdateSeriesInput{Status: &status})
+}
+
+func (u *comicUsecase) DeleteSeries(req Requester, id uuid.UUID) error {
+	series, err := authorizeSeries(u.comicRepo, req, id)
+	if err != nil {
+		return err
+	}
+	var chapterIDs []uuid.UUID
+	for _, season := range series.Seasons {
+		for _, c := range season.Chapters {
+			chapterIDs = append(chapterIDs, c.ID)
+		}
+	}
+	pages, err := u.comicRepo.ListChapterImageKeys(chapterIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := u.comicRepo.DeleteSeries(id); err != nil {
+		return err
+	}
+	u.releaseImages(append(seriesImageKeys(series), pages...))
+	return nil
 }
 
-func (u *comicUsecase) GetChapter(id uuid.UUID) (*domain.Chapter, error) {
-	return u.comicRepo.GetChapterByID(id)
+func (u *comicUsecase) ListCollaborators(req Requester, seriesID uuid.UUID) ([]domain.SeriesCollaborator, error) {
+	if _, err := authorizeSeries(u.comicRepo, req, seriesID); err != nil {
+		return nil, err
+	}
+	return u.comicRepo.ListSeriesCollaborators(seriesID)
 }
 
-func (u *comicUsecase) ListSeries() ([]domain.Series, error) {
-	return u.comicRepo.ListSeries()
+func (u *comicUsecase) AddCollaborator(req Requester, seriesID, userID uuid.UUID) (*domain.SeriesCollaborator, error) {
+	series, err := authorizeSeries(u.comicRepo, req, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if userID == series.CreatorID {
+		return nil, fmt.Errorf("%w: the creator already owns this series", ErrInvalidInput)
+	}
+	if _, err := u.userRepo.FindByID(userID); err != nil {
+		return nil, notFound(err)
+	}
+
+	collaborator := &domain.SeriesCollaborator{SeriesID: seriesID, UserID: userID}
+	if err := u.comicRepo.AddSeriesCollaborator(collaborator); err != nil {
+		return nil, err
+	}
+	return collaborator, nil
+}
+
+func (u *comicUsecase) RemoveCollaborator(req Requester, seriesID, userID uuid.UUID) error {
+	if _, err := authorizeSeries(u.comicRepo, req, seriesID); err != nil {
+		return err
+	}
+	return notFound(u.comicRepo.RemoveSeriesCollaborator(seriesID, userID))
+}
+
+// PublishScheduled flips scheduled series and chapters whose time has come
+// to published. It is safe to call concurrently from several instances.
+func (u *comicUsecase) PublishScheduled(now time.Time) (int64, int64, error) {
+	return u.comicRepo.PublishScheduled(now)
+}
+
+func validateSeriesStatus(status domain.SeriesStatus) error {
+	switch status {
+	case domain.SeriesDraft, domain.SeriesPublished, domain.SeriesHiatus, domain.SeriesCompleted, domain.SeriesArchived:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown series status %q", ErrInvalidInput, status)
+}
+
+func validateVisibility(visibility string) error {
+	switch visibility {
+	case domain.VisibilityPublic, domain.VisibilityPrivate, domain.VisibilityUnlisted:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown visibility %q", ErrInvalidInput, visibility)
+}
+
+func validateWatermark(mode domain.WatermarkMode) error {
+	switch mode {
+	case domain.WatermarkNone, domain.WatermarkVisible, domain.WatermarkInvisible, domain.WatermarkBoth:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown watermark mode %q", ErrInvalidInput, mode)
+}
+
+type SeasonInput struct {
+	SeasonNumber int    `json:"season_number"`
+	Title        string `json:"title"`
+}
+
+type UpdateSeasonInput struct {
+	SeasonNumber *int    `json:"season_number"`
+	Title        *string `json:"title"`
+}
+
+type ChapterInput struct {
+	ChapterNumber     int                  `json:"chapter_number"`
+	Title             string               `json:"title"`
+	Status            domain.ChapterStatus `json:"status"`
+	SchedulePublishAt *time.Time           `json:"schedule_publish_at"`
+}
+
+type UpdateChapterInput struct {
+	ChapterNumber     *int                  `json:"chapter_number"`
+	Title             *string               `json:"title"`
+	Status            *domain.ChapterStatus `json:"status"`
+	SchedulePublishAt *time.Time            `json:"schedule_publish_at"`
+}
+
+func (u *comicUsecase) ListSeasons(req Requester, seriesID uuid.UUID) ([]domain.Season, error) {
+	if _, err := authorizeSeries(u.comicRepo, req, seriesID); err != nil {
+		return nil, err
+	}
+	return u.comicRepo.ListSeasonsBySeriesID(seriesID)
+}
+
+func (u *comicUsecase) CreateSeason(req Requester, seriesID uuid.UUID, input SeasonInput) (*domain.Season, error) {
+	series, err := authorizeSeries(u.comicRepo, req, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if input.SeasonNumber < 1 {
+		return nil, fmt.Errorf("%w: season number must be positive", ErrInvalidInput)
+	}
+	for _, s := range series.Seasons {
+		if s.SeasonNumber == input.SeasonNumber {
+			return nil, fmt.Errorf("%w: season %d", ErrConflict, input.SeasonNumber)
+		}
+	}
+
+	season := &domain.Season{
+		ID:           uuid.New(),
+		SeriesID:     seriesID,
+		SeasonNumber: input.SeasonNumber,
+		Title:        input.Title,
+	}
+	if err := u.comicRepo.CreateSeason(season); err != nil {
+		return nil, err
+	}
+	return season, nil
+}
+
+func (u *comicUsecase) UpdateSeason(req Requester, seasonID uuid.UUID, input UpdateSeasonInput) (*domain.Season, error) {
+	season, err := authorizeSeason(u.comicRepo, req, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.SeasonNumber != nil && *input.SeasonNumber != season.SeasonNumber {
+		if *input.SeasonNumber < 1 {
+			return nil, fmt.Errorf("%w: season number must be positive", ErrInvalidInput)
+		}
+		siblings, err := u.comicRepo.ListSeasonsBySeriesID(season.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range siblings {
+			if s.SeasonNumber == *input.SeasonNumber {
+				return nil, fmt.Errorf("%w: season %d", ErrConflict, *input.SeasonNumber)
+			}
+		}
+		season.SeasonNumber = *input.SeasonNumber
+	}
+	if input.Title != nil {
+		season.Title = *input.Title
+	}
+
+	if err := u.comicRepo.UpdateSeason(season); err != nil {
+		return nil, err
+	}
+	return season, nil
+}
+
+func (u *comicUsecase) DeleteSeason(req Requester, seasonID uuid.UUID) error {
+	season, err := authorizeSeason(u.comicRepo, req, seasonID)
+	if err != nil {
+		return err
+	}
+	chapterIDs := make([]uuid.UUID, 0, len(season.Chapters))
+	for _, c := range season.Chapters {
+		chapterIDs = append(chapterIDs, c.ID)
+	}
+	pages, err := u.comicRepo.ListChapterImageKeys(chapterIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := u.comicRepo.DeleteSeason(seasonID); err != nil {
+		return err
+	}
+	u.releaseImages(pages)
+	return nil
+}
+
+func (u *comicUsecase) CreateChapter(req Requester, seasonID uuid.UUID, input ChapterInput) (*domain.Chapter, error) {
+	season, err := authorizeSeason(u.comicRepo, req, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if input.ChapterNumber < 1 {
+		return nil, fmt.Errorf("%w: chapter number must be positive", ErrInvalidInput)
+	}
+	for _, c := range season.Chapters {
+		if c.ChapterNumber == input.ChapterNumber {
+			return nil, fmt.Errorf("%w: chapter %d", ErrConflict, input.ChapterNumber)
+		}
+	}
+	if input.Status == "" {
+		input.Status = defaultChapterStatus(input.SchedulePublishAt)
+	}
+
+	chapter := &domain.Chapter{
+		ID:                uuid.New(),
+		SeasonID:          seasonID,
+		ChapterNumber:     input.ChapterNumber,
+		Title:             input.Title,
+		SchedulePublishAt: input.SchedulePublishAt,
+	}
+	if err := applyChapterStatus(chapter, input.Status); err != nil {
+		return nil, err
+	}
+
+	if err := u.comicRepo.CreateChapter(chapter); err != nil {
+		return nil, err
+	}
+	return chapter, nil
+}
+
+func (u *comicUsecase) UpdateChapter(req Requester, chapterID uuid.UUID, input UpdateChapterInput) (*domain.Chapter, error) {
+	chapter, err := authorizeChapter(u.comicRepo, req, chapterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ChapterNumber != nil && *input.ChapterNumber != chapter.ChapterNumber {
+		if *input.ChapterNumber < 1 {
+			return nil, fmt.Errorf("%w: chapter number must be positive", ErrInvalidInput)
+		}
+		season, err := u.comicRepo.GetSeasonByID(chapter.SeasonID)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range season.Chapters {
+			if c.ChapterNumber == *input.ChapterNumber {
+				return nil, fmt.Errorf("%w: chapter %d", ErrConflict, *input.ChapterNumber)
+			}
+		}
+		chapter.ChapterNumber = *input.ChapterNumber
+	}
+	if input.Title != nil {
+		chapter.Title = *input.Title
+	}
+	if input.SchedulePublishAt != nil {
+		chapter.SchedulePublishAt = input.SchedulePublishAt
+	}
+	status := chapter.Status
+	if input.Status != nil {
+		status = *input.Status
+	} else if input.SchedulePublishAt != nil {
+		// Sending only a schedule means scheduling the chapter; applying
+		// its current draft or published status would drop the time.
+		status = domain.ChapterScheduled
+	}
+	if input.Status != nil || input.SchedulePublishAt != nil {
+		if err := applyChapterStatus(chapter, status); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.comicRepo.UpdateChapter(chapter); err != nil {
+		return nil, err
+	}
+	return chapter, nil
+}
+
+func (u *comicUsecase) DeleteChapter(req Requester, chapterID uuid.UUID) error {
+	chapter, err := authorizeChapter(u.comicRepo, req, chapterID)
+	if err != nil {
+		return err
+	}
+	if err := u.comicRepo.DeleteChapter(chapterID); err != nil {
+		return err
+	}
+	pages := make([]domain.AssetKey, 0, len(chapter.Images))
+	for _, img := range chapter.Images {
+		pages = append(pages, img.ImageURL)
+	}
+	u.releaseImages(pages)
+	return nil
+}
+
+func (u *comicUsecase) AddChapterPages(req Requester, chapterID uuid.UUID, files []FileUpload) ([]domain.ChapterImage, error) {
+	if _, err := authorizeChapter(u.comicRepo, req, chapterID); err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w: no pages uploaded", ErrInvalidInput)
+	}
+
+	// Pages stored before a failure are released, though as fresh uploads
+	// they are only removed later by storage GC.
+	images := make([]domain.ChapterImage, 0, len(files))
+	stored := make([]domain.AssetKey, 0, len(files))
+	for _, f := range files {
+		saved, err := u.uploadUsecase.SaveImage(f.Filename, f.Reader)
+		if err != nil {
+			u.uploadUsecase.ReleaseImages(stored)
+			return nil, err
+		}
+		image := saved.chapterImage()
+		image.ID = uuid.New()
+		images = append(images, image)
+		stored = append(stored, image.ImageURL)
+	}
+
+	if err := u.comicRepo.AddChapterImages(chapterID, images); err != nil {
+		u.uploadUsecase.ReleaseImages(stored)
+		return nil, err
+	}
+	return images, nil
+}
+
+func (u *comicUsecase) ReorderChapterPages(req Requester, chapterID uuid.UUID, imageIDs []uuid.UUID) ([]domain.ChapterImage, error) {
+	chapter, err := authorizeChapter(u.comicRepo, req, chapterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(imageIDs) != len(chapter.Images) {
+		return nil, fmt.Errorf("%w: expected %d image IDs, got %d", ErrInvalidInput, len(chapter.Images), len(imageIDs))
+	}
+	remaining := make(map[uuid.UUID]bool, len(chapter.Images))
+	for _, img := range chapter.Images {
+		remaining[img.ID] = true
+	}
+	for _, id := range imageIDs {
+		if !remaining[id] {
+			return nil, fmt.Errorf("%w: image %s is not in this chapter or is listed twice", ErrInvalidInput, id)
+		}
+		delete(remaining, id)
+	}
+
+	return u.comicRepo.ReorderChapterImages(chapterID, imageIDs)
+}
+
+func (u *comicUsecase) ReplaceChapterPage(req Requester, imageID uuid.UUID, file FileUpload) (*domain.ChapterImage, error) {
+	image, err := authorizeChapterImage(u.comicRepo, req, imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := u.uploadUsecase.SaveImage(file.Filename, file.Reader)
+	if err != nil {
+		return nil, err
+	}
+	previous := image.ImageURL
+	stored.applyTo(image)
+
+	if err := u.comicRepo.UpdateChapterImage(image); err != nil {
+		return nil, err
+	}
+	u.releaseImages([]domain.AssetKey{previous})
+	return image, nil
+}
+
+func (u *comicUsecase) DeleteChapterPage(req Requester, imageID uuid.UUID) error {
+	image, err := authorizeChapterImage(u.comicRepo, req, imageID)
+	if err != nil {
+		return err
+	}
+	if err := u.comicRepo.DeleteChapterImage(imageID); err != nil {
+		return err
+	}
+	u.releaseImages([]domain.AssetKey{image.ImageURL})
+	return nil
+}
+
+// releaseImages deletes files that an edit or delete left unreferenced.
+// The change itself has already succeeded, so a failure here is not
+// reported; it only leaves unreferenced files behind.
+func (u *comicUsecase) releaseImages(keys []domain.AssetKey) {
+	u.uploadUsecase.ReleaseImages(keys)
+}
+
+func seriesImageKeys(series *domain.Series) []domain.AssetKey {
+	return []domain.AssetKey{series.ThumbnailURL, series.CoverImageURL, series.BannerImageURL}
+}
+
+// defaultChapterStatus is the status of a new chapter sent without one: a
+// schedule implies scheduled, otherwise it starts as a draft.
+func defaultChapterStatus(schedulePublishAt *time.Time) domain.ChapterStatus {
+	if schedulePublishAt != nil {
+		return domain.ChapterScheduled
+	}
+	return domain.ChapterDraft
+}
+
+// applyChapterStatus moves a chapter to the given status, stamping
+// PublishedAt the first time it is published. Scheduled chapters must have a
+// future SchedulePublishAt; other statuses clear it.
+func applyChapterStatus(chapter *domain.Chapter, status domain.ChapterStatus) error {
+	switch status {
+	case domain.ChapterDraft:
+		chapter.SchedulePublishAt = nil
+	case domain.ChapterScheduled:
+		if chapter.SchedulePublishAt == nil || !chapter.SchedulePublishAt.After(time.Now()) {
+			return fmt.Errorf("%w: scheduled chapters need a future schedule_publish_at", ErrInvalidInput)
+		}
+	case domain.ChapterPublished:
+		chapter.SchedulePublishAt = nil
+		if chapter.PublishedAt == nil {
+			now := time.Now()
+			chapter.PublishedAt = &now
+		}
+	default:
+		return fmt.Errorf("%w: unknown chapter status %q", ErrInvalidInput, status)
+	}
+	chapter.Status = status
+	return nil
 }
@@ -0,0 +1,576 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	_ "golang.org/x/image/webp"
+)
+
+type ExportFormat string
+
+const (
+	ExportCBZ  ExportFormat = "cbz"
+	ExportEPUB ExportFormat = "epub"
+)
+
+type ExportScope string
+
+const (
+	ExportScopeChapter ExportScope = "chapter"
+	ExportScopeSeason  ExportScope = "season"
+	ExportScopeSeries  ExportScope = "series"
+)
+
+type ExportInput struct {
+	Scope  ExportScope
+	ID     uuid.UUID
+	Format ExportFormat
+	// Language selects which TextLayer translations are overlaid on the
+	// pages of an EPUB. Empty exports the artwork only.
+	Language string
+}
+
+// ExportPlan is an authorised export whose content has been looked up but
+// not yet read from storage.
+type ExportPlan struct {
+	Format      ExportFormat
+	Filename    string
+	ContentType string
+
+	id       uuid.UUID
+	language string
+	series   *domain.Series
+	chapters []exportChapter
+}
+
+type exportChapter struct {
+	season  domain.Season
+	chapter *domain.Chapter
+}
+
+type ExportUsecase interface {
+	// PrepareExport checks access and loads everything an export needs, so
+	// errors surface before any output is written.
+	PrepareExport(req Requester, input ExportInput) (*ExportPlan, error)
+	// WriteExport streams the packaged archive to w.
+	WriteExport(plan *ExportPlan, w io.Writer) error
+}
+
+type exportUsecase struct {
+	comicRepo     domain.ComicRepository
+	uploadUsecase UploadUsecase
+}
+
+func NewExportUsecase(comicRepo domain.ComicRepository, uploadUsecase UploadUsecase) ExportUsecase {
+	return &exportUsecase{comicRepo, uploadUsecase}
+}
+
+func (u *exportUsecase) PrepareExport(req Requester, input ExportInput) (*ExportPlan, error) {
+	plan := &ExportPlan{Format: input.Format, id: input.ID, language: input.Language}
+	switch input.Format {
+	case ExportCBZ:
+		plan.ContentType = "application/vnd.comicbook+zip"
+	case ExportEPUB:
+		plan.ContentType = "application/epub+zip"
+	default:
+		return nil, fmt.Errorf("%w: unknown export format %q", ErrInvalidInput, input.Format)
+	}
+
+	var seriesID uuid.UUID
+	var seasons []domain.Season
+	switch input.Scope {
+	case ExportScopeChapter:
+		chapter, err := authorizeChapter(u.comicRepo, req, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		season, err := u.comicRepo.GetSeasonByID(chapter.SeasonID)
+		if err != nil {
+			return nil, err
+		}
+		seriesID = season.SeriesID
+		plan.chapters = []exportChapter{{season: *season, chapter: chapter}}
+	case ExportScopeSeason:
+		season, err := authorizeSeason(u.comicRepo, req, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		seriesID = season.SeriesID
+		seasons = []domain.Season{*season}
+	case ExportScopeSeries:
+		seriesID = input.ID
+	default:
+		return nil, fmt.Errorf("%w: unknown export scope %q", ErrInvalidInput, input.Scope)
+	}
+
+	series, err := authorizeSeries(u.comicRepo, req, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	plan.series = series
+	if input.Scope == ExportScopeSeries {
+		seasons = series.Seasons
+	}
+
+	for _, season := range seasons {
+		for _, c := range season.Chapters {
+			chapter, err := u.comicRepo.GetChapterByID(c.ID)
+			if err != nil {
+				return nil, err
+			}
+			plan.chapters = append(plan.chapters, exportChapter{season: season, chapter: chapter})
+		}
+	}
+	if len(plan.chapters) == 0 {
+		return nil, fmt.Errorf("%w: nothing to export", ErrInvalidInput)
+	}
+
+	name := slugify(localizedTitle(series.Title, input.Language))
+	if name == "" {
+		name = series.ID.String()
+	}
+	switch input.Scope {
+	case ExportScopeChapter:
+		c := plan.chapters[0]
+		name = fmt.Sprintf("%s-s%02d-c%03d", name, c.season.SeasonNumber, c.chapter.ChapterNumber)
+	case ExportScopeSeason:
+		name = fmt.Sprintf("%s-s%02d", name, plan.chapters[0].season.SeasonNumber)
+	}
+	plan.Filename = name + "." + string(input.Format)
+
+	return plan, nil
+}
+
+func (u *exportUsecase) WriteExport(plan *ExportPlan, w io.Writer) error {
+	if plan.Format == ExportEPUB {
+		return u.writeEPUB(plan, w)
+	}
+	return u.writeCBZ(plan, w)
+}
+
+// writeCBZ lays a single chapter out at the archive root. Larger exports get
+// one folder per chapter, each with its own ComicInfo.xml, which is the
+// layout ImportChapters reads back.
+func (u *exportUsecase) writeCBZ(plan *ExportPlan, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	single := len(plan.chapters) == 1
+	if !single {
+		if err := writeComicInfo(zw, "ComicInfo.xml", newComicInfo(plan.series, nil, plan.language)); err != nil {
+			return err
+		}
+	}
+
+	for _, ec := range plan.chapters {
+		dir := ""
+		if !single {
+			dir = fmt.Sprintf("S%02d C%03d/", ec.season.SeasonNumber, ec.chapter.ChapterNumber)
+		}
+		info := newComicInfo(plan.series, &ec, plan.language)
+		if err := writeComicInfo(zw, dir+"ComicInfo.xml", info); err != nil {
+			return err
+		}
+
+		for i, img := range ec.chapter.Images {
+			data, err := u.readImage(img.ImageURL)
+			if err != nil {
+				return err
+			}
+			name := fmt.Sprintf("%s%04d%s", dir, i+1, imageExt(img.ImageURL))
+			// Images are already compressed; storing them saves CPU.
+			fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: time.Now()})
+			if err != nil {
+				return err
+			}
+			if _, err := fw.Write(data); err != nil {
+				return err
+			}
+		}
+	}
+	return zw.Close()
+}
+
+// exportComicInfo is the ComicRack ComicInfo.xml written into CBZ exports.
+type exportComicInfo struct {
+	XMLName     xml.Name `xml:"ComicInfo"`
+	Title       string   `xml:"Title,omitempty"`
+	Series      string   `xml:"Series"`
+	Number      string   `xml:"Number,omitempty"`
+	Volume      int      `xml:"Volume,omitempty"`
+	Summary     string   `xml:"Summary,omitempty"`
+	Writer      string   `xml:"Writer,omitempty"`
+	Genre       string   `xml:"Genre,omitempty"`
+	Tags        string   `xml:"Tags,omitempty"`
+	PageCount   int      `xml:"PageCount,omitempty"`
+	LanguageISO string   `xml:"LanguageISO,omitempty"`
+	AgeRating   string   `xml:"AgeRating,omitempty"`
+}
+
+func newComicInfo(series *domain.Series, ec *exportChapter, language string) exportComicInfo {
+	var tags []string
+	for _, t := range series.Tags {
+		tags = append(tags, t.Slug)
+	}
+	info := exportComicInfo{
+		Series:      localizedTitle(series.Title, language),
+		Summary:     localizedTitle(series.Description, language),
+		Writer:      series.Author,
+		Genre:       strings.Join(series.Genres, ", "),
+		Tags:        strings.Join(tags, ", "),
+		LanguageISO: language,
+	}
+	if series.NSFW {
+		info.AgeRating = "Adults Only 18+"
+	}
+	if ec != nil {
+		info.Title = ec.chapter.Title
+		info.Number = fmt.Sprint(ec.chapter.ChapterNumber)
+		info.Volume = ec.season.SeasonNumber
+		info.PageCount = len(ec.chapter.Images)
+	}
+	return info
+}
+
+func writeComicInfo(zw *zip.Writer, name string, info exportComicInfo) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(fw, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(fw)
+	enc.Indent("", "  ")
+	return enc.Encode(info)
+}
+
+// epubPage is one fixed-layout page of an EPUB export.
+type epubPage struct {
+	ID        string
+	ImageID   string
+	ImageHref string
+	MediaType string
+	Width     int
+	Height    int
+	Layers    []epubLayer
+	// ChapterTitle is set on the first page of each chapter for the TOC.
+	ChapterTitle string
+}
+
+type epubLayer struct {
+	Type                      domain.TextLayerType
+	X, Y, Width, Height       int
+	Text, FontSize, FontColor string
+}
+
+// writeEPUB produces a fixed-layout EPUB 3 with one page per image. When a
+// language is chosen, text layers with a translation in that language are
+// overlaid on the artwork as positioned HTML.
+func (u *exportUsecase) writeEPUB(plan *ExportPlan, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	// The mimetype entry must come first and be stored uncompressed.
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(fw, "application/epub+zip"); err != nil {
+		return err
+	}
+	if err := writeZipString(zw, "META-INF/container.xml", epubContainerXML); err != nil {
+		return err
+	}
+	if err := writeZipString(zw, "OEBPS/style.css", epubStyleCSS); err != nil {
+		return err
+	}
+
+	var pages []epubPage
+	for _, ec := range plan.chapters {
+		for i, img := range ec.chapter.Images {
+			data, err := u.readImage(img.ImageURL)
+			if err != nil {
+				return err
+			}
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+			if err != nil {
+				return fmt.Errorf("decode %s: %w", img.ImageURL, err)
+			}
+
+			n := len(pages) + 1
+			ext := imageExt(img.ImageURL)
+			page := epubPage{
+				ID:        fmt.Sprintf("p%04d", n),
+				ImageID:   fmt.Sprintf("img%04d", n),
+				ImageHref: fmt.Sprintf("images/%04d%s", n, ext),
+				MediaType: imageMediaType(ext),
+				Width:     cfg.Width,
+				Height:    cfg.Height,
+				Layers:    epubLayers(img.TextLayers, plan.language, cfg.Width, cfg.Height),
+			}
+			if i == 0 {
+				page.ChapterTitle = chapterLabel(ec)
+			}
+
+			fw, err := zw.CreateHeader(&zip.FileHeader{Name: "OEBPS/" + page.ImageHref, Method: zip.Store, Modified: time.Now()})
+			if err != nil {
+				return err
+			}
+			if _, err := fw.Write(data); err != nil {
+				return err
+			}
+			if err := writeZipTemplate(zw, "OEBPS/pages/"+page.ID+".xhtml", epubPageTemplate, page); err != nil {
+				return err
+			}
+			pages = append(pages, page)
+		}
+	}
+	if len(pages) == 0 {
+		return fmt.Errorf("%w: nothing to export", ErrInvalidInput)
+	}
+
+	language := plan.language
+	if language == "" {
+		language = "en"
+	}
+	title := localizedTitle(plan.series.Title, plan.language)
+	if len(plan.chapters) == 1 {
+		title += " - " + chapterLabel(plan.chapters[0])
+	}
+	pkg := map[string]interface{}{
+		"ID":       plan.id,
+		"Title":    title,
+		"Author":   plan.series.Author,
+		"Language": language,
+		"Modified": time.Now().UTC().Format("2006-01-02T15:04:05Z"),
+		"Pages":    pages,
+	}
+	if err := writeZipTemplate(zw, "OEBPS/content.opf", epubPackageTemplate, pkg); err != nil {
+		return err
+	}
+	if err := writeZipTemplate(zw, "OEBPS/nav.xhtml", epubNavTemplate, pkg); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// epubLayers lays out the translated text layers of a width x height page.
+// Layer boxes are stored as percentages of the page, so they are scaled to
+// the page's pixels here, and the default font size follows the scaled box.
+func epubLayers(layers []domain.TextLayer, language string, width, height int) []epubLayer {
+	if language == "" {
+		return nil
+	}
+	var out []epubLayer
+	for _, l := range layers {
+		text := ""
+		for _, t := range l.Translations {
+			if t.LanguageCode == language {
+				text = t.TranslatedText
+				break
+			}
+		}
+		if text == "" {
+			continue
+		}
+
+		var style struct {
+			FontSize json.Number `json:"fontSize"`
+			Color    string      `json:"color"`
+		}
+		_ = json.Unmarshal(l.StyleJSON, &style)
+		boxHeight := l.Height * height / 100
+		fontSize := boxHeight / 4
+		if n, err := style.FontSize.Int64(); err == nil && n > 0 {
+			fontSize = int(n)
+		}
+		if fontSize < 10 {
+			fontSize = 10
+		}
+
+		out = append(out, epubLayer{
+			Type:      l.Type,
+			X:         l.PositionX * width / 100,
+			Y:         l.PositionY * height / 100,
+			Width:     l.Width * width / 100,
+			Height:    boxHeight,
+			Text:      text,
+			FontSize:  fmt.Sprintf("%dpx", fontSize),
+			FontColor: cssColor(style.Color),
+		})
+	}
+	return out
+}
+
+// cssColor passes through colour values that are safe to put in a style
+// attribute (names, hex, rgb()/hsl()) and drops anything else.
+func cssColor(c string) string {
+	for _, r := range c {
+		if !(r == '#' || r == '(' || r == ')' || r == ',' || r == '.' || r == '%' || r == ' ' ||
+			(r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')) {
+			return ""
+		}
+	}
+	return c
+}
+
+func (u *exportUsecase) readImage(key domain.AssetKey) ([]byte, error) {
+	rc, err := u.uploadUsecase.Open(key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func chapterLabel(ec exportChapter) string {
+	label := fmt.Sprintf("Season %d, Chapter %d", ec.season.SeasonNumber, ec.chapter.ChapterNumber)
+	if ec.chapter.Title != "" {
+		label += ": " + ec.chapter.Title
+	}
+	return label
+}
+
+func imageExt(key domain.AssetKey) string {
+	ext := strings.ToLower(path.Ext(string(key)))
+	if ext == ".jpeg" {
+		return ".jpg"
+	}
+	return ext
+}
+
+func imageMediaType(ext string) string {
+	switch ext {
+	case ".png":
+		return "image/png"
+	case ".gif":
+		return "image/gif"
+	case ".webp":
+		return "image/webp"
+	default:
+		return "image/jpeg"
+	}
+}
+
+func writeZipString(zw *zip.Writer, name, content string) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(fw, content)
+	return err
+}
+
+func writeZipTemplate(zw *zip.Writer, name string, tmpl *template.Template, data interface{}) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(fw, data)
+}
+
+// xmlEscape is the escaper used by the EPUB templates, which are XML and so
+// can't use html/template's contextual escaping.
+func xmlEscape(v interface{}) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(fmt.Sprint(v)))
+	return b.String()
+}
+
+const epubContainerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+const epubStyleCSS = `body { margin: 0; padding: 0; }
+.page { position: relative; overflow: hidden; }
+.page img { position: absolute; top: 0; left: 0; }
+.layer { position: absolute; display: flex; align-items: center; justify-content: center;
+  text-align: center; overflow: hidden; font-family: sans-serif; line-height: 1.2; color: #000; }
+.layer-bubble, .layer-narration { background: #fff; }
+.layer-sfx { font-weight: bold; }
+`
+
+var epubFuncs = template.FuncMap{"x": xmlEscape}
+
+var epubPackageTemplate = template.Must(template.New("opf").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="bookid" prefix="rendition: http://www.idpf.org/vocab/rendition/#">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="bookid">urn:uuid:{{.ID}}</dc:identifier>
+    <dc:title>{{x .Title}}</dc:title>
+    {{- if .Author}}
+    <dc:creator>{{x .Author}}</dc:creator>
+    {{- end}}
+    <dc:language>{{x .Language}}</dc:language>
+    <meta property="dcterms:modified">{{.Modified}}</meta>
+    <meta property="rendition:layout">pre-paginated</meta>
+    <meta property="rendition:spread">none</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="css" href="style.css" media-type="text/css"/>
+    {{- range $i, $p := .Pages}}
+    <item id="{{$p.ImageID}}" href="{{$p.ImageHref}}" media-type="{{$p.MediaType}}"{{if eq $i 0}} properties="cover-image"{{end}}/>
+    <item id="{{$p.ID}}" href="pages/{{$p.ID}}.xhtml" media-type="application/xhtml+xml"/>
+    {{- end}}
+  </manifest>
+  <spine>
+    {{- range .Pages}}
+    <itemref idref="{{.ID}}"/>
+    {{- end}}
+  </spine>
+</package>
+`))
+
+var epubNavTemplate = template.Must(template.New("nav").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head><title>{{x .Title}}</title></head>
+<body>
+  <nav epub:type="toc" id="toc">
+    <h1>{{x .Title}}</h1>
+    <ol>
+      {{- range .Pages}}{{if .ChapterTitle}}
+      <li><a href="pages/{{.ID}}.xhtml">{{x .ChapterTitle}}</a></li>
+      {{- end}}{{end}}
+    </ol>
+  </nav>
+</body>
+</html>
+`))
+
+var epubPageTemplate = template.Must(template.New("page").Funcs(epubFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<head>
+  <title>{{.ID}}</title>
+  <meta name="viewport" content="width={{.Width}}, height={{.Height}}"/>
+  <link rel="stylesheet" type="text/css" href="../style.css"/>
+</head>
+<body>
+  <div class="page" style="width: {{.Width}}px; height: {{.Height}}px;">
+    <img src="../{{.ImageHref}}" alt="" width="{{.Width}}" height="{{.Height}}"/>
+    {{- range .Layers}}
+    <div class="layer layer-{{x .Type}}" style="left: {{.X}}px; top: {{.Y}}px; width: {{.Width}}px; height: {{.Height}}px; font-size: {{.FontSize}};{{if .FontColor}} color: {{x .FontColor}};{{end}}">{{x .Text}}</div>
+    {{- end}}
+  </div>
+</body>
+</html>
+`))
@@ -0,0 +1,361 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// thumbnailWidth is the width of the grid thumbnail; taller images are
+	// cropped from the top to thumbnailAspect first.
+	thumbnailWidth  = 320
+	thumbnailAspect = 1.5
+	variantQuality  = 85
+)
+
+// readerVariantWidths are the widths offered to the reader for responsive
+// loading. Only widths narrower than the original are generated.
+var readerVariantWidths = []int{480, 720, 1080, 1440}
+
+// formatExts maps the formats image.Decode reports to the extension the
+// original is stored under, regardless of what the client named it.
+var formatExts = map[string]string{
+	"jpeg": ".jpg",
+	"png":  ".png",
+	"gif":  ".gif",
+	"webp": ".webp",
+}
+
+// validatedImage is an upload that passed validation, with its metadata
+// stripped.
+type validatedImage struct {
+	original []byte
+	ext      string
+	width    int
+	height   int
+}
+
+type encodedImage struct {
+	data   []byte
+	ext    string
+	width  int
+	height int
+}
+
+// validateImage checks data against limits, identifying the format by its
+// magic bytes rather than the client's filename, and strips its metadata.
+// Only the header is decoded; renderVariants decodes the pixels.
+func validateImage(data []byte, limits UploadLimits) (*validatedImage, error) {
+	format := sniffImageFormat(data)
+	ext, ok := formatExts[format]
+	if !ok {
+		return nil, uploadError(UploadCodeUnsupportedType, "file is not a JPEG, PNG, GIF or WebP image")
+	}
+	if !limits.AllowAnimated && isAnimated(format, data) {
+		return nil, uploadError(UploadCodeAnimated, "animated images are not allowed")
+	}
+
+	cfg, decoded, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decoded != format {
+		return nil, uploadError(UploadCodeCorrupt, "image header is unreadable")
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, uploadError(UploadCodeCorrupt, "image has no pixels")
+	}
+	if cfg.Width > limits.MaxWidth || cfg.Height > limits.MaxHeight {
+		return nil, uploadError(UploadCodeDimensionsTooBig, "image is %dx%d; the limit is %dx%d",
+			cfg.Width, cfg.Height, limits.MaxWidth, limits.MaxHeight)
+	}
+	if cfg.Width*cfg.Height > limits.MaxPixels {
+		return nil, uploadError(UploadCodeTooManyPixels, "image has %d pixels; the limit is %d",
+			cfg.Width*cfg.Height, limits.MaxPixels)
+	}
+
+	original, err := stripMetadata(format, data)
+	if err != nil {
+		return nil, uploadError(UploadCodeCorrupt, "%v", err)
+	}
+	return &validatedImage{
+		original: original,
+		ext:      ext,
+		width:    cfg.Width,
+		height:   cfg.Height,
+	}, nil
+}
+
+// renderedImage holds the files derived from an upload.
+type renderedImage struct {
+	thumbnail encodedImage
+	variants  []encodedImage
+	tiles     []encodedTile
+}
+
+// renderVariants decodes the image, rejecting corrupt pixel data, and
+// produces its thumbnail, reader variants and, for tall images, tiles.
+func renderVariants(v *validatedImage) (*renderedImage, error) {
+	img, _, err := image.Decode(bytes.NewReader(v.original))
+	if err != nil {
+		return nil, uploadError(UploadCodeCorrupt, "image data is corrupt: %v", err)
+	}
+
+	out := &renderedImage{}
+	if out.thumbnail, err = encodeVariant(thumbnailOf(img), thumbnailWidth); err != nil {
+		return nil, err
+	}
+	for _, w := range readerVariantWidths {
+		if w >= v.width {
+			break
+		}
+		variant, err := encodeVariant(img, w)
+		if err != nil {
+			return nil, err
+		}
+		out.variants = append(out.variants, variant)
+	}
+	if out.tiles, err = renderTiles(img); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// thumbnailOf crops img from the top so it is no taller than
+// thumbnailAspect times its width.
+func thumbnailOf(img image.Image) image.Image {
+	b := img.Bounds()
+	maxHeight := int(float64(b.Dx()) * thumbnailAspect)
+	if b.Dy() <= maxHeight {
+		return img
+	}
+	return cropImage(img, image.Rect(b.Min.X, b.Min.Y, b.Max.X, b.Min.Y+maxHeight))
+}
+
+func cropImage(img image.Image, crop image.Rectangle) image.Image {
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(crop)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, crop.Dx(), crop.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, crop.Min, draw.Src)
+	return dst
+}
+
+// encodeVariant scales img down to width (never up) and encodes it as JPEG,
+// or PNG when the image has transparency. Re-encoding also drops all
+// metadata.
+func encodeVariant(img image.Image, width int) (encodedImage, error) {
+	b := img.Bounds()
+	if b.Dx() > width {
+		height := b.Dy() * width / b.Dx()
+		if height < 1 {
+			height = 1
+		}
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+		img = dst
+	}
+
+	var buf bytes.Buffer
+	out := encodedImage{width: img.Bounds().Dx(), height: img.Bounds().Dy()}
+	if isOpaque(img) {
+		out.ext = ".jpg"
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: variantQuality}); err != nil {
+			return out, err
+		}
+	} else {
+		out.ext = ".png"
+		if err := png.Encode(&buf, img); err != nil {
+			return out, err
+		}
+	}
+	out.data = buf.Bytes()
+	return out, nil
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+// stripMetadata removes EXIF, XMP and text metadata from an encoded image
+// without re-encoding it, so originals keep their full quality. GIF carries
+// no EXIF and is stored as-is to keep animations intact.
+func stripMetadata(format string, data []byte) ([]byte, error) {
+	switch format {
+	case "jpeg":
+		return stripJPEG(data)
+	case "png":
+		return stripPNG(data)
+	case "webp":
+		return stripWebP(data)
+	case "gif":
+		return data, nil
+	}
+	return nil, fmt.Errorf("unsupported image format %q", format)
+}
+
+// stripJPEG drops APP1 (EXIF/XMP), APP13 (IPTC) and comment segments. APP0,
+// APP2 (ICC profile) and APP14 (Adobe colour transform) are kept because
+// they affect how the image is rendered.
+func stripJPEG(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, fmt.Errorf("malformed JPEG")
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	i := 2
+	for i < len(data) {
+		if i+1 >= len(data) || data[i] != 0xFF {
+			return nil, fmt.Errorf("malformed JPEG segment at %d", i)
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			// Fill byte.
+			i++
+			continue
+		case marker == 0xD9:
+			return append(out, data[i:]...), nil
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			// Standalone markers have no length.
+			out = append(out, data[i:i+2]...)
+			i += 2
+			continue
+		}
+		if i+4 > len(data) {
+			return nil, fmt.Errorf("truncated JPEG")
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) {
+			return nil, fmt.Errorf("truncated JPEG")
+		}
+		if marker == 0xDA {
+			// Start of scan: the rest is entropy-coded data.
+			return append(out, data[i:]...), nil
+		}
+		if marker != 0xE1 && marker != 0xED && marker != 0xFE {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	return out, nil
+}
+
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+func stripPNG(data []byte) ([]byte, error) {
+	const sigLen = 8
+	if len(data) < sigLen {
+		return nil, fmt.Errorf("malformed PNG")
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:sigLen]...)
+	for i := sigLen; i < len(data); {
+		if i+8 > len(data) {
+			return nil, fmt.Errorf("truncated PNG")
+		}
+		// length, type, data, CRC
+		end := i + 12 + int(binary.BigEndian.Uint32(data[i:]))
+		if end > len(data) || end < i {
+			return nil, fmt.Errorf("truncated PNG")
+		}
+		if !pngMetadataChunks[string(data[i+4:i+8])] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	return out, nil
+}
+
+// stripWebP drops the EXIF and XMP chunks, clears their flags in the VP8X
+// header and fixes up the RIFF size.
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("malformed WebP")
+	}
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, fmt.Errorf("truncated WebP")
+		}
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2
+		if end > len(data) || end < i {
+			return nil, fmt.Errorf("truncated WebP")
+		}
+		switch string(data[i : i+4]) {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			start := len(out)
+			out = append(out, data[i:end]...)
+			if size > 0 {
+				// Bit 3 is EXIF, bit 2 is XMP.
+				out[start+8] &^= 0x08 | 0x04
+			}
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
+
+// StoredImage describes an image after it has been processed and saved.
+type StoredImage struct {
+	URL          domain.AssetKey      `json:"url"`
+	Width        int                  `json:"width"`
+	Height       int                  `json:"height"`
+	ThumbnailURL domain.AssetKey      `json:"thumbnail_url"`
+	Variants     domain.ImageVariants `json:"variants"`
+	Tiles        domain.ImageTiles    `json:"tiles"`
+}
+
+func newStoredImage(blob *domain.Blob) *StoredImage {
+	variants := blob.Variants
+	if variants == nil {
+		variants = domain.ImageVariants{}
+	}
+	return &StoredImage{
+		URL:          blob.OriginalKey,
+		Width:        blob.Width,
+		Height:       blob.Height,
+		ThumbnailURL: blob.ThumbnailKey,
+		Variants:     variants,
+		Tiles:        blob.Tiles,
+	}
+}
+
+// chapterImage builds the ChapterImage row for a stored page.
+func (s *StoredImage) chapterImage() domain.ChapterImage {
+	var img domain.ChapterImage
+	s.applyTo(&img)
+	return img
+}
+
+func (s *StoredImage) applyTo(img *domain.ChapterImage) {
+	img.ImageURL = s.URL
+	img.Width = s.Width
+	img.Height = s.Height
+	img.ThumbnailURL = s.ThumbnailURL
+	img.Variants = s.Variants
+	img.Tiles = s.Tiles
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"image"
+)
+
+const (
+	// tileThreshold is the height above which a page is split into tiles.
+	// Webtoon strips are commonly 800px wide and 20000px or more tall,
+	// which mobile browsers struggle to decode in one piece.
+	tileThreshold = 4000
+	// tileHeight is the height tiles aim for. A cut may move up to
+	// tileSearch rows either way to land in a gutter.
+	tileHeight = 1600
+	tileSearch = 400
+	// gutterMinRows is how many consecutive flat rows count as a gutter,
+	// so a single flat line inside a panel isn't cut through.
+	gutterMinRows = 8
+	// gutterTolerance is how far, per 16-bit channel, a pixel may stray
+	// from the start of its row for the row to count as flat.
+	gutterTolerance = 0x0C00
+	gutterSamples   = 64
+)
+
+type encodedTile struct {
+	encodedImage
+	y int
+}
+
+// renderTiles slices a tall image into tiles, cutting in the middle of
+// the whitespace gutter between panels nearest each tileHeight step and at
+// exactly tileHeight where there is none. Images no taller than
+// tileThreshold aren't tiled.
+func renderTiles(img image.Image) ([]encodedTile, error) {
+	b := img.Bounds()
+	if b.Dy() <= tileThreshold {
+		return nil, nil
+	}
+
+	var tiles []encodedTile
+	for y := b.Min.Y; y < b.Max.Y; {
+		end := b.Max.Y
+		// The last tile takes whatever is left rather than leaving a sliver.
+		if b.Max.Y-y > tileHeight+tileSearch {
+			end = y + tileHeight
+			if cut, ok := findGutter(img, end-tileSearch, end+tileSearch, end); ok {
+				end = cut
+			}
+		}
+
+		tile, err := encodeVariant(cropImage(img, image.Rect(b.Min.X, y, b.Max.X, end)), b.Dx())
+		if err != nil {
+			return nil, err
+		}
+		tiles = append(tiles, encodedTile{encodedImage: tile, y: y - b.Min.Y})
+		y = end
+	}
+	return tiles, nil
+}
+
+// findGutter looks for runs of flat rows between lo and hi and returns the
+// middle of the one closest to target.
+func findGutter(img image.Image, lo, hi, target int) (int, bool) {
+	best, found := 0, false
+	runStart := -1
+	for y := lo; y <= hi; y++ {
+		if y < hi && isFlatRow(img, y) {
+			if runStart < 0 {
+				runStart = y
+			}
+			continue
+		}
+		if runStart >= 0 && y-runStart >= gutterMinRows {
+			mid := (runStart + y) / 2
+			if !found || abs(mid-target) < abs(best-target) {
+				best, found = mid, true
+			}
+		}
+		runStart = -1
+	}
+	return best, found
+}
+
+// isFlatRow reports whether row y is a single colour, judged from
+// gutterSamples pixels spread across it.
+func isFlatRow(img image.Image, y int) bool {
+	b := img.Bounds()
+	step := b.Dx() / gutterSamples
+	if step < 1 {
+		step = 1
+	}
+	r0, g0, b0, _ := img.At(b.Min.X, y).RGBA()
+	for x := b.Min.X + step; x < b.Max.X; x += step {
+		r, g, bl, _ := img.At(x, y).RGBA()
+		if channelDiff(r, r0) > gutterTolerance || channelDiff(g, g0) > gutterTolerance || channelDiff(bl, b0) > gutterTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func channelDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
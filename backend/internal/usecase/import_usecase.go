@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+)
+
+const (
+	maxArchivePages            = 2000
+	maxArchiveUncompressedSize = 2 << 30 // 2 GiB
+)
+
+type ImportUsecase interface {
+	// ImportChapters creates chapters in a season from a CBZ/ZIP archive.
+	// Images at the top level form a single chapter; otherwise each
+	// top-level folder is a chapter. A ComicInfo.xml next to the images
+	// supplies the chapter's title and number. defaults fills in whatever
+	// the archive doesn't specify.
+	ImportChapters(req Requester, seasonID uuid.UUID, archive io.ReaderAt, size int64, defaults ChapterInput) ([]domain.Chapter, error)
+}
+
+type importUsecase struct {
+	comicRepo     domain.ComicRepository
+	uploadUsecase UploadUsecase
+}
+
+func NewImportUsecase(comicRepo domain.ComicRepository, uploadUsecase UploadUsecase) ImportUsecase {
+	return &importUsecase{comicRepo, uploadUsecase}
+}
+
+// comicInfo is the subset of the ComicRack ComicInfo.xml schema we use.
+type comicInfo struct {
+	Title  string `xml:"Title"`
+	Number string `xml:"Number"`
+}
+
+// archiveChapter is a chapter found in an archive, before it is stored.
+type archiveChapter struct {
+	dir   string
+	info  *comicInfo
+	pages []*zip.File
+}
+
+func (u *importUsecase) ImportChapters(req Requester, seasonID uuid.UUID, archive io.ReaderAt, size int64, defaults ChapterInput) ([]domain.Chapter, error) {
+	season, err := authorizeSeason(u.comicRepo, req, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a valid CBZ/ZIP archive", ErrInvalidInput)
+	}
+	found, err := readArchiveChapters(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make(map[int]bool, len(season.Chapters))
+	next := 1
+	for _, c := range season.Chapters {
+		taken[c.ChapterNumber] = true
+		if c.ChapterNumber >= next {
+			next = c.ChapterNumber + 1
+		}
+	}
+
+	status := defaults.Status
+	if status == "" {
+		status = domain.ChapterDraft
+	}
+
+	// Work out every chapter's number before storing anything, so a
+	// conflict is reported before any work is done.
+	chapters := make([]domain.Chapter, len(found))
+	for i, ac := range found {
+		number := 0
+		title := defaults.Title
+		if ac.info != nil {
+			if n, err := strconv.Atoi(strings.TrimSpace(ac.info.Number)); err == nil {
+				number = n
+			}
+			if ac.info.Title != "" {
+				title = ac.info.Title
+			}
+		}
+		if number == 0 && len(found) == 1 && defaults.ChapterNumber > 0 {
+			number = defaults.ChapterNumber
+		}
+		if number == 0 {
+			number = next
+		}
+		if number < 1 {
+			return nil, fmt.Errorf("%w: chapter number must be positive", ErrInvalidInput)
+		}
+		if taken[number] {
+			return nil, fmt.Errorf("%w: chapter %d", ErrConflict, number)
+		}
+		taken[number] = true
+		if number >= next {
+			next = number + 1
+		}
+		if title == "" && len(found) > 1 {
+			title = path.Base(ac.dir)
+		}
+
+		chapters[i] = domain.Chapter{
+			ID:                uuid.New(),
+			SeasonID:          seasonID,
+			ChapterNumber:     number,
+			Title:             title,
+			SchedulePublishAt: defaults.SchedulePublishAt,
+		}
+		if err := applyChapterStatus(&chapters[i], status); err != nil {
+			return nil, err
+		}
+	}
+
+	// Store every page first, then create all chapters in one transaction,
+	// so a failure anywhere leaves the season as it was. Pages stored for a
+	// failed import are released, though as fresh uploads they are within
+	// releaseGracePeriod and are only removed later by storage GC.
+	var stored []domain.AssetKey
+	for i, ac := range found {
+		images, err := u.storePages(ac.pages)
+		for _, img := range images {
+			stored = append(stored, img.ImageURL)
+		}
+		if err != nil {
+			u.uploadUsecase.ReleaseImages(stored)
+			return nil, err
+		}
+		chapters[i].Images = images
+	}
+	if err := u.comicRepo.CreateChapters(chapters); err != nil {
+		u.uploadUsecase.ReleaseImages(stored)
+		return nil, err
+	}
+	return chapters, nil
+}
+
+// storePages stores a chapter's pages. On error it also returns the pages
+// stored before the failure.
+func (u *importUsecase) storePages(pages []*zip.File) ([]domain.ChapterImage, error) {
+	images := make([]domain.ChapterImage, 0, len(pages))
+	for i, f := range pages {
+		rc, err := f.Open()
+		if err != nil {
+			return images, fmt.Errorf("%w: cannot read %s", ErrInvalidInput, f.Name)
+		}
+		stored, err := u.uploadUsecase.SaveImage(path.Base(f.Name), rc)
+		rc.Close()
+		if err != nil {
+			return images, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		image := stored.chapterImage()
+		image.ID = uuid.New()
+		image.Order = i + 1
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// readArchiveChapters groups the archive's images into chapters, each with
+// its pages in natural order.
+func readArchiveChapters(zr *zip.Reader) ([]*archiveChapter, error) {
+	byDir := make(map[string]*archiveChapter)
+	var total uint64
+	pageCount := 0
+
+	chapterFor := func(dir string) *archiveChapter {
+		if byDir[dir] == nil {
+			byDir[dir] = &archiveChapter{dir: dir}
+		}
+		return byDir[dir]
+	}
+
+	for _, f := range zr.File {
+		name := strings.ReplaceAll(f.Name, "\\", "/")
+		if f.FileInfo().IsDir() || isJunkEntry(name) {
+			continue
+		}
+
+		dir := path.Dir(name)
+		if dir == "." {
+			dir = ""
+		}
+		// Pages may sit a level deeper than the chapter folder; only the
+		// top-level folder identifies the chapter.
+		if i := strings.Index(dir, "/"); i >= 0 {
+			dir = dir[:i]
+		}
+
+		base := path.Base(name)
+		if strings.EqualFold(base, "ComicInfo.xml") {
+			info, err := readComicInfo(f)
+			if err != nil {
+				return nil, err
+			}
+			chapterFor(dir).info = info
+			continue
+		}
+		if !allowedImageExts[strings.ToLower(path.Ext(base))] {
+			continue
+		}
+
+		total += f.UncompressedSize64
+		pageCount++
+		if total > maxArchiveUncompressedSize {
+			return nil, fmt.Errorf("%w: archive is too large once extracted", ErrInvalidInput)
+		}
+		if pageCount > maxArchivePages {
+			return nil, fmt.Errorf("%w: archive has more than %d pages", ErrInvalidInput, maxArchivePages)
+		}
+		ac := chapterFor(dir)
+		ac.pages = append(ac.pages, f)
+	}
+
+	// Loose top-level images mean the whole archive is one chapter, even if
+	// it also contains folders (e.g. extras); a root ComicInfo.xml applies.
+	var chapters []*archiveChapter
+	if root := byDir[""]; root != nil && len(root.pages) > 0 {
+		for dir, ac := range byDir {
+			if dir != "" {
+				root.pages = append(root.pages, ac.pages...)
+			}
+		}
+		chapters = []*archiveChapter{root}
+	} else {
+		for dir, ac := range byDir {
+			if dir != "" && len(ac.pages) > 0 {
+				chapters = append(chapters, ac)
+			}
+		}
+		sort.Slice(chapters, func(i, j int) bool {
+			return naturalLess(chapters[i].dir, chapters[j].dir)
+		})
+		if len(chapters) == 1 && chapters[0].info == nil && byDir[""] != nil {
+			chapters[0].info = byDir[""].info
+		}
+	}
+	if len(chapters) == 0 {
+		return nil, fmt.Errorf("%w: archive contains no images", ErrInvalidInput)
+	}
+
+	for _, ac := range chapters {
+		sort.SliceStable(ac.pages, func(i, j int) bool {
+			return naturalLess(ac.pages[i].Name, ac.pages[j].Name)
+		})
+	}
+	return chapters, nil
+}
+
+func readComicInfo(f *zip.File) (*comicInfo, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read %s", ErrInvalidInput, f.Name)
+	}
+	defer rc.Close()
+
+	var info comicInfo
+	if err := xml.NewDecoder(io.LimitReader(rc, 1<<20)).Decode(&info); err != nil {
+		return nil, fmt.Errorf("%w: invalid %s: %v", ErrInvalidInput, f.Name, err)
+	}
+	return &info, nil
+}
+
+// isJunkEntry skips metadata that archivers add, such as macOS resource
+// forks and hidden files.
+func isJunkEntry(name string) bool {
+	if strings.HasPrefix(name, "__MACOSX/") {
+		return true
+	}
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// naturalLess orders strings so that embedded numbers compare by value,
+// e.g. "page2.jpg" before "page10.jpg". Letters compare case-insensitively.
+func naturalLess(a, b string) bool {
+	ar, br := []rune(a), []rune(b)
+	i, j := 0, 0
+	for i < len(ar) && j < len(br) {
+		if unicode.IsDigit(ar[i]) && unicode.IsDigit(br[j]) {
+			si := i
+			for i < len(ar) && unicode.IsDigit(ar[i]) {
+				i++
+			}
+			sj := j
+			for j < len(br) && unicode.IsDigit(br[j]) {
+				j++
+			}
+			na := strings.TrimLeft(string(ar[si:i]), "0")
+			nb := strings.TrimLeft(string(br[sj:j]), "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			continue
+		}
+		ca, cb := unicode.ToLower(ar[i]), unicode.ToLower(br[j])
+		if ca != cb {
+			return ca < cb
+		}
+		i++
+		j++
+	}
+	return len(ar)-i < len(br)-j
+}
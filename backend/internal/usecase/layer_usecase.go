@@ -1,24 +1,39 @@
 package usecase
 
 import (
+	"fmt"
+
 	"github.com/google/uuid"
 	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"gorm.io/datatypes"
 )
 
 type LayerUsecase interface {
-	AddLayer(imageID uuid.UUID, text string, x, y, w, h int, layerType domain.TextLayerType) (*domain.TextLayer, error)
-	TranslateLayer(layerID uuid.UUID, targetLang string) (*domain.Translation, error)
+	AddLayer(req Requester, imageID uuid.UUID, text string, x, y, w, h int, layerType domain.TextLayerType) (*domain.TextLayer, error)
+	ListLayers(req Requester, imageID uuid.UUID) ([]domain.TextLayer, error)
+	GetLayer(req Requester, layerID uuid.UUID) (*domain.TextLayer, error)
+	UpdateLayer(req Requester, layerID uuid.UUID, input UpdateLayerInput) (*domain.TextLayer, error)
+	DeleteLayer(req Requester, layerID uuid.UUID) error
+	// SaveLayers replaces every layer on the image with layers, as the
+	// layer editor saves them, and returns the result.
+	SaveLayers(req Requester, imageID uuid.UUID, layers []LayerInput) ([]domain.TextLayer, error)
+	TranslateLayer(req Requester, layerID uuid.UUID, targetLang string) (*domain.Translation, error)
 }
 
 type layerUsecase struct {
 	layerRepo domain.LayerRepository
+	comicRepo domain.ComicRepository
 }
 
-func NewLayerUsecase(layerRepo domain.LayerRepository) LayerUsecase {
-	return &layerUsecase{layerRepo}
+func NewLayerUsecase(layerRepo domain.LayerRepository, comicRepo domain.ComicRepository) LayerUsecase {
+	return &layerUsecase{layerRepo, comicRepo}
 }
 
-func (u *layerUsecase) AddLayer(imageID uuid.UUID, text string, x, y, w, h int, layerType domain.TextLayerType) (*domain.TextLayer, error) {
+func (u *layerUsecase) AddLayer(req Requester, imageID uuid.UUID, text string, x, y, w, h int, layerType domain.TextLayerType) (*domain.TextLayer, error) {
+	if err := authorizeImageLayers(u.comicRepo, req, imageID); err != nil {
+		return nil, err
+	}
+
 	layer := &domain.TextLayer{
 		ID:             uuid.New(),
 		ChapterImageID: imageID,
@@ -29,6 +44,12 @@ func (u *layerUsecase) AddLayer(imageID uuid.UUID, text string, x, y, w, h int,
 		Height:         h,
 		Type:           layerType,
 	}
+	if layer.Type == "" {
+		layer.Type = domain.LayerBubble
+	}
+	if err := validateLayer(layer); err != nil {
+		return nil, err
+	}
 
 	if err := u.layerRepo.CreateLayer(layer); err != nil {
 		return nil, err
@@ -37,7 +58,143 @@ func (u *layerUsecase) AddLayer(imageID uuid.UUID, text string, x, y, w, h int,
 	return layer, nil
 }
 
-func (u *layerUsecase) TranslateLayer(layerID uuid.UUID, targetLang string) (*domain.Translation, error) {
+func (u *layerUsecase) ListLayers(req Requester, imageID uuid.UUID) ([]domain.TextLayer, error) {
+	if err := authorizeImageLayers(u.comicRepo, req, imageID); err != nil {
+		return nil, err
+	}
+	return u.layerRepo.GetLayersByImageID(imageID)
+}
+
+// GetLayer loads a layer the requester may edit.
+func (u *layerUsecase) GetLayer(req Requester, layerID uuid.UUID) (*domain.TextLayer, error) {
+	layer, err := u.layerRepo.GetLayerByID(layerID)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if err := authorizeImageLayers(u.comicRepo, req, layer.ChapterImageID); err != nil {
+		return nil, err
+	}
+	return layer, nil
+}
+
+// UpdateLayerInput is a partial update; nil fields are left unchanged.
+type UpdateLayerInput struct {
+	OriginalText *string               `json:"original_text"`
+	PositionX    *int                  `json:"position_x"`
+	PositionY    *int                  `json:"position_y"`
+	Width        *int                  `json:"width"`
+	Height       *int                  `json:"height"`
+	StyleJSON    *datatypes.JSON       `json:"style_json"`
+	Type         *domain.TextLayerType `json:"type"`
+}
+
+func (u *layerUsecase) UpdateLayer(req Requester, layerID uuid.UUID, input UpdateLayerInput) (*domain.TextLayer, error) {
+	layer, err := u.GetLayer(req, layerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.OriginalText != nil {
+		layer.OriginalText = *input.OriginalText
+	}
+	if input.PositionX != nil {
+		layer.PositionX = *input.PositionX
+	}
+	if input.PositionY != nil {
+		layer.PositionY = *input.PositionY
+	}
+	if input.Width != nil {
+		layer.Width = *input.Width
+	}
+	if input.Height != nil {
+		layer.Height = *input.Height
+	}
+	if input.StyleJSON != nil {
+		layer.StyleJSON = *input.StyleJSON
+	}
+	if input.Type != nil {
+		layer.Type = *input.Type
+	}
+	if err := validateLayer(layer); err != nil {
+		return nil, err
+	}
+
+	if err := u.layerRepo.UpdateLayer(layer); err != nil {
+		return nil, err
+	}
+	return layer, nil
+}
+
+func (u *layerUsecase) DeleteLayer(req Requester, layerID uuid.UUID) error {
+	if _, err := u.GetLayer(req, layerID); err != nil {
+		return err
+	}
+	return notFound(u.layerRepo.DeleteLayer(layerID))
+}
+
+// LayerInput is one layer in a bulk save. ID is the layer being kept; new
+// layers leave it empty.
+type LayerInput struct {
+	ID           uuid.UUID            `json:"id"`
+	OriginalText string               `json:"original_text"`
+	PositionX    int                  `json:"position_x"`
+	PositionY    int                  `json:"position_y"`
+	Width        int                  `json:"width"`
+	Height       int                  `json:"height"`
+	StyleJSON    datatypes.JSON       `json:"style_json"`
+	Type         domain.TextLayerType `json:"type"`
+}
+
+func (u *layerUsecase) SaveLayers(req Requester, imageID uuid.UUID, inputs []LayerInput) ([]domain.TextLayer, error) {
+	if err := authorizeImageLayers(u.comicRepo, req, imageID); err != nil {
+		return nil, err
+	}
+
+	layers := make([]domain.TextLayer, len(inputs))
+	for i, in := range inputs {
+		layers[i] = domain.TextLayer{
+			ID:           in.ID,
+			OriginalText: in.OriginalText,
+			PositionX:    in.PositionX,
+			PositionY:    in.PositionY,
+			Width:        in.Width,
+			Height:       in.Height,
+			StyleJSON:    in.StyleJSON,
+			Type:         in.Type,
+		}
+		if layers[i].Type == "" {
+			layers[i].Type = domain.LayerBubble
+		}
+		if err := validateLayer(&layers[i]); err != nil {
+			return nil, fmt.Errorf("layer %d: %w", i, err)
+		}
+	}
+
+	if err := u.layerRepo.ReplaceLayers(imageID, layers); err != nil {
+		return nil, notFound(err)
+	}
+	return u.layerRepo.GetLayersByImageID(imageID)
+}
+
+// validateLayer checks the layer type and that its box has no negative
+// size. Positions are percentages of the page, as the reader draws them.
+func validateLayer(layer *domain.TextLayer) error {
+	switch layer.Type {
+	case domain.LayerBubble, domain.LayerNarration, domain.LayerSFX:
+	default:
+		return fmt.Errorf("%w: unknown layer type %q", ErrInvalidInput, layer.Type)
+	}
+	if layer.Width < 0 || layer.Height < 0 {
+		return fmt.Errorf("%w: layer width and height must not be negative", ErrInvalidInput)
+	}
+	return nil
+}
+
+func (u *layerUsecase) TranslateLayer(req Requester, layerID uuid.UUID, targetLang string) (*domain.Translation, error) {
+	if _, err := u.GetLayer(req, layerID); err != nil {
+		return nil, err
+	}
+
 	// STUB: Mock translation logic
 	// In a real system, this would call an AI service (OpenAI, Google Translate, etc.)
 	// For now, we just return a mock translation.
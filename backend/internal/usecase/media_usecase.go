@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"github.com/pur108/ebook-platform/backend/internal/watermark"
+)
+
+// DefaultMediaURLTTL is how long a signed page URL stays valid: long
+// enough to read a chapter, short enough that shared links go stale.
+const DefaultMediaURLTTL = 15 * time.Minute
+
+// MediaConfig configures signed media URLs.
+type MediaConfig struct {
+	Secret []byte
+	// BaseURL is the absolute URL the API is reachable at; signed URLs
+	// point at its /api/media route.
+	BaseURL string
+	TTL     time.Duration
+	// BindUser ties URLs issued to a signed-in reader to their account, so
+	// the media request must carry the same user's token. Only enable it
+	// for clients that send the token with image requests.
+	BindUser bool
+	// WatermarkSecret keys the invisible watermark; cmd/watermark_decode
+	// needs the same one.
+	WatermarkSecret []byte
+}
+
+// SignedMedia is the signature part of a signed media URL.
+type SignedMedia struct {
+	Expires   string
+	UserID    string
+	Watermark string
+	Signature string
+}
+
+// MediaFile is a file opened through a signed URL.
+type MediaFile struct {
+	Body        io.ReadCloser
+	ContentType string
+	// Expires is when the URL stops working.
+	Expires time.Time
+}
+
+type MediaUsecase interface {
+	// SignURL returns a short-lived URL that serves key to viewer, marked
+	// for them according to mark.
+	SignURL(key domain.AssetKey, viewer Requester, mark domain.WatermarkMode) domain.AssetKey
+	// OpenSigned checks a signed URL's signature, expiry and user and
+	// opens the file, watermarking it if the URL asks for that. token is
+	// the URL's last path segment.
+	OpenSigned(viewer Requester, token string, signed SignedMedia) (*MediaFile, error)
+	// IsProtected reports whether key may only be served through a signed
+	// URL.
+	IsProtected(key string) (bool, error)
+}
+
+type mediaUsecase struct {
+	comicRepo     domain.ComicRepository
+	uploadUsecase UploadUsecase
+	cfg           MediaConfig
+}
+
+func NewMediaUsecase(comicRepo domain.ComicRepository, uploadUsecase UploadUsecase, cfg MediaConfig) MediaUsecase {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultMediaURLTTL
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &mediaUsecase{comicRepo, uploadUsecase, cfg}
+}
+
+func (u *mediaUsecase) SignURL(key domain.AssetKey, viewer Requester, mark domain.WatermarkMode) domain.AssetKey {
+	if key == "" || key.IsExternal() {
+		return key
+	}
+	signed := SignedMedia{Expires: strconv.FormatInt(time.Now().Add(u.cfg.TTL).Unix(), 10)}
+	query := url.Values{"expires": {signed.Expires}}
+	if viewer.UserID != uuid.Nil && (u.cfg.BindUser || mark.Marks()) {
+		signed.UserID = viewer.UserID.String()
+		query.Set("user", signed.UserID)
+		if mark.Marks() {
+			signed.Watermark = string(mark)
+			query.Set("watermark", signed.Watermark)
+		}
+	}
+	query.Set("signature", u.sign(string(key), signed))
+
+	token, err := u.sealKey(string(key))
+	if err != nil {
+		// Only a broken random source gets here; a URL that doesn't work
+		// is better than one that exposes the key.
+		return ""
+	}
+	return domain.AssetKey(u.cfg.BaseURL + "/api/media/" + token + "?" + query.Encode())
+}
+
+func (u *mediaUsecase) OpenSigned(viewer Requester, token string, signed SignedMedia) (*MediaFile, error) {
+	key, err := u.openKey(token)
+	if err != nil || !hmac.Equal([]byte(u.sign(key, signed)), []byte(signed.Signature)) {
+		return nil, fmt.Errorf("%w: invalid media signature", ErrForbidden)
+	}
+	unix, err := strconv.ParseInt(signed.Expires, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid media signature", ErrForbidden)
+	}
+	file := &MediaFile{
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		Expires:     time.Unix(unix, 0),
+	}
+	if time.Now().After(file.Expires) {
+		return nil, fmt.Errorf("%w: media link has expired", ErrForbidden)
+	}
+	if u.cfg.BindUser && signed.UserID != "" && signed.UserID != viewer.UserID.String() {
+		return nil, fmt.Errorf("%w: media link was issued to another user", ErrForbidden)
+	}
+
+	if file.Body, err = u.uploadUsecase.Open(domain.AssetKey(key)); err != nil {
+		return nil, err
+	}
+	if mark := domain.WatermarkMode(signed.Watermark); mark.Marks() {
+		if err := u.watermark(file, signed.UserID, mark); err != nil {
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+// sealKey encrypts a storage key for a media URL's path. On S3 and CDN
+// deployments the original file is publicly readable at its key, so the
+// key must not appear in links handed to readers.
+func (u *mediaUsecase) sealKey(key string) (string, error) {
+	aead, err := u.pathCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(key), nil)), nil
+}
+
+// openKey reverses sealKey.
+func (u *mediaUsecase) openKey(token string) (string, error) {
+	aead, err := u.pathCipher()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("invalid media token")
+	}
+	key, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+func (u *mediaUsecase) pathCipher() (cipher.AEAD, error) {
+	secret := sha256.Sum256(append([]byte("media-path:"), u.cfg.Secret...))
+	block, err := aes.NewCipher(secret[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sign MACs the fields of a media URL. Newlines can't occur in any of
+// them, so the encoding is unambiguous.
+func (u *mediaUsecase) sign(key string, signed SignedMedia) string {
+	mac := hmac.New(sha256.New, u.cfg.Secret)
+	mac.Write([]byte(strings.Join([]string{key, signed.Expires, signed.UserID, signed.Watermark}, "\n")))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// watermark replaces file's body with a copy marked for userID. GIFs are
+// left alone so animations keep working.
+func (u *mediaUsecase) watermark(file *MediaFile, userID string, mark domain.WatermarkMode) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		file.Body.Close()
+		return fmt.Errorf("%w: invalid media signature", ErrForbidden)
+	}
+	data, err := io.ReadAll(file.Body)
+	file.Body.Close()
+	if err != nil {
+		return err
+	}
+	file.Body = io.NopCloser(bytes.NewReader(data))
+	if sniffImageFormat(data) == "gif" {
+		return nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	marked := watermark.Embed(img, id, u.cfg.WatermarkSecret, watermark.Options{
+		Visible:   mark.Visible(),
+		Invisible: mark.Invisible(),
+	})
+	encoded, err := encodeVariant(marked, marked.Bounds().Dx())
+	if err != nil {
+		return err
+	}
+	file.Body = io.NopCloser(bytes.NewReader(encoded.data))
+	file.ContentType = mime.TypeByExtension(encoded.ext)
+	return nil
+}
+
+func (u *mediaUsecase) IsProtected(key string) (bool, error) {
+	return u.comicRepo.IsProtectedPageKey(domain.AssetKey(key))
+}
+
+// signChapterImages replaces the page URLs of a chapter with signed ones.
+func signChapterImages(media MediaUsecase, viewer Requester, mark domain.WatermarkMode, images []domain.ChapterImage) {
+	for i := range images {
+		img := &images[i]
+		img.ImageURL = media.SignURL(img.ImageURL, viewer, mark)
+		img.ThumbnailURL = media.SignURL(img.ThumbnailURL, viewer, mark)
+		variants := make(domain.ImageVariants, len(img.Variants))
+		for j, v := range img.Variants {
+			v.URL = media.SignURL(v.URL, viewer, mark)
+			variants[j] = v
+		}
+		img.Variants = variants
+		tiles := make(domain.ImageTiles, len(img.Tiles))
+		for j, t := range img.Tiles {
+			t.URL = media.SignURL(t.URL, viewer, mark)
+			tiles[j] = t
+		}
+		img.Tiles = tiles
+	}
+}
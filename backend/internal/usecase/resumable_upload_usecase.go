@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+)
+
+// UploadSessionTTL is how long a resumable upload may sit idle before it is
+// discarded. Every received chunk extends it.
+const UploadSessionTTL = 24 * time.Hour
+
+// ErrChecksumMismatch means a chunk's content doesn't match the checksum the
+// client sent with it; the chunk is discarded and may be retried.
+var ErrChecksumMismatch = errors.New("chunk checksum mismatch")
+
+type ResumableUploadUsecase interface {
+	CreateUpload(req Requester, filename string, size int64) (*domain.UploadSession, error)
+	GetUpload(req Requester, id uuid.UUID) (*domain.UploadSession, error)
+	// WriteChunk appends data at offset, which must be the session's
+	// current offset. checksum is optional and has the form
+	// "sha256 <base64 digest>".
+	WriteChunk(req Requester, id uuid.UUID, offset int64, data []byte, checksum string) (*domain.UploadSession, error)
+	// CompleteUpload runs a fully received upload through the image pipeline
+	// and discards the session.
+	CompleteUpload(req Requester, id uuid.UUID) (*StoredImage, error)
+	// OpenUpload reads back a fully received upload so it can be handed to
+	// another usecase, e.g. as a chapter page. The caller closes the reader
+	// and deletes the upload afterwards.
+	OpenUpload(req Requester, id uuid.UUID) (FileUpload, io.Closer, error)
+	DeleteUpload(req Requester, id uuid.UUID) error
+	// ExpireUploads deletes sessions that have been idle past their expiry,
+	// returning how many were removed.
+	ExpireUploads(now time.Time) (int, error)
+}
+
+type resumableUploadUsecase struct {
+	sessionRepo   domain.UploadSessionRepository
+	storage       domain.FileStorage
+	uploadUsecase UploadUsecase
+	maxSize       int64
+}
+
+func NewResumableUploadUsecase(sessionRepo domain.UploadSessionRepository, storage domain.FileStorage, uploadUsecase UploadUsecase, limits UploadLimits) ResumableUploadUsecase {
+	return &resumableUploadUsecase{sessionRepo, storage, uploadUsecase, limits.MaxBytes}
+}
+
+func (u *resumableUploadUsecase) CreateUpload(req Requester, filename string, size int64) (*domain.UploadSession, error) {
+	filename = filepath.Base(strings.TrimSpace(filename))
+	if !allowedImageExts[strings.ToLower(filepath.Ext(filename))] {
+		return nil, uploadError(UploadCodeUnsupportedType, "invalid file type, only images are allowed")
+	}
+	if size <= 0 {
+		return nil, uploadError(UploadCodeEmpty, "upload length must be positive")
+	}
+	if size > u.maxSize {
+		return nil, uploadError(UploadCodeTooLarge, "file exceeds the %d byte limit", u.maxSize)
+	}
+
+	now := time.Now()
+	session := &domain.UploadSession{
+		ID:        uuid.New(),
+		UserID:    req.UserID,
+		Filename:  filename,
+		Size:      size,
+		ExpiresAt: now.Add(UploadSessionTTL),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.sessionRepo.CreateSession(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetUpload reports sessions belonging to someone else, or past their
+// expiry, as not found.
+func (u *resumableUploadUsecase) GetUpload(req Requester, id uuid.UUID) (*domain.UploadSession, error) {
+	session, err := u.sessionRepo.GetSession(id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if session.UserID != req.UserID || time.Now().After(session.ExpiresAt) {
+		return nil, ErrNotFound
+	}
+	return session, nil
+}
+
+func (u *resumableUploadUsecase) WriteChunk(req Requester, id uuid.UUID, offset int64, data []byte, checksum string) (*domain.UploadSession, error) {
+	session, err := u.GetUpload(req, id)
+	if err != nil {
+		return nil, err
+	}
+	if offset != session.Offset {
+		return nil, fmt.Errorf("%w: upload is at offset %d, not %d", ErrConflict, session.Offset, offset)
+	}
+	if offset+int64(len(data)) > session.Size {
+		return nil, fmt.Errorf("%w: chunk runs past the declared upload length", ErrInvalidInput)
+	}
+	if len(data) == 0 {
+		return session, nil
+	}
+	if err := verifyChecksum(checksum, data); err != nil {
+		return nil, err
+	}
+
+	chunk := &domain.UploadChunk{
+		ID:        uuid.New(),
+		SessionID: session.ID,
+		Offset:    offset,
+		Size:      int64(len(data)),
+	}
+	chunk.Key = fmt.Sprintf("resumable/%s/%s", session.ID, chunk.ID)
+	if err := u.storage.Put(chunk.Key, bytes.NewReader(data), chunk.Size, "application/octet-stream"); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(UploadSessionTTL)
+	if err := u.sessionRepo.AppendChunk(chunk, expiresAt); err != nil {
+		u.storage.Delete(chunk.Key)
+		if errors.Is(err, domain.ErrStaleUploadOffset) {
+			return nil, fmt.Errorf("%w: another chunk was written at offset %d first", ErrConflict, offset)
+		}
+		return nil, err
+	}
+	session.Offset += chunk.Size
+	session.ExpiresAt = expiresAt
+	session.Chunks = append(session.Chunks, *chunk)
+	return session, nil
+}
+
+// verifyChecksum checks data against a tus-style "sha256 <base64>" value.
+// An empty checksum is accepted.
+func verifyChecksum(checksum string, data []byte) error {
+	if checksum == "" {
+		return nil
+	}
+	algo, digest, ok := strings.Cut(strings.TrimSpace(checksum), " ")
+	if !ok || !strings.EqualFold(algo, "sha256") {
+		return fmt.Errorf("%w: checksum must be \"sha256 <base64 digest>\"", ErrInvalidInput)
+	}
+	want, err := base64.StdEncoding.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("%w: checksum digest is not base64", ErrInvalidInput)
+	}
+	got := sha256.Sum256(data)
+	if !bytes.Equal(got[:], want) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+func (u *resumableUploadUsecase) CompleteUpload(req Requester, id uuid.UUID) (*StoredImage, error) {
+	file, closer, err := u.OpenUpload(req, id)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := u.uploadUsecase.SaveImage(file.Filename, file.Reader)
+	closer.Close()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.DeleteUpload(req, id); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (u *resumableUploadUsecase) OpenUpload(req Requester, id uuid.UUID) (FileUpload, io.Closer, error) {
+	session, err := u.GetUpload(req, id)
+	if err != nil {
+		return FileUpload{}, nil, err
+	}
+	if !session.Complete() {
+		return FileUpload{}, nil, fmt.Errorf("%w: upload %s has %d of %d bytes", ErrInvalidInput, id, session.Offset, session.Size)
+	}
+	r := &chunkReader{storage: u.storage, chunks: session.Chunks}
+	return FileUpload{Filename: session.Filename, Reader: r}, r, nil
+}
+
+func (u *resumableUploadUsecase) DeleteUpload(req Requester, id uuid.UUID) error {
+	session, err := u.GetUpload(req, id)
+	if err != nil {
+		return err
+	}
+	return u.deleteSession(session)
+}
+
+func (u *resumableUploadUsecase) deleteSession(session *domain.UploadSession) error {
+	for _, c := range session.Chunks {
+		if err := u.storage.Delete(c.Key); err != nil {
+			return err
+		}
+	}
+	return u.sessionRepo.DeleteSession(session.ID)
+}
+
+func (u *resumableUploadUsecase) ExpireUploads(now time.Time) (int, error) {
+	const batch = 100
+	removed := 0
+	for {
+		sessions, err := u.sessionRepo.ListExpiredSessions(now, batch)
+		if err != nil {
+			return removed, err
+		}
+		for i := range sessions {
+			if err := u.deleteSession(&sessions[i]); err != nil {
+				return removed, err
+			}
+			removed++
+		}
+		if len(sessions) < batch {
+			return removed, nil
+		}
+	}
+}
+
+// chunkReader reads an upload's chunks back to back, opening each one only
+// when the previous is exhausted.
+type chunkReader struct {
+	storage domain.FileStorage
+	chunks  []domain.UploadChunk
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.chunks) == 0 {
+				return 0, io.EOF
+			}
+			rc, err := r.storage.Get(r.chunks[0].Key)
+			if err != nil {
+				return 0, err
+			}
+			r.current = rc
+			r.chunks = r.chunks[1:]
+		}
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.current != nil {
+		return r.current.Close()
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+)
+
+const (
+	maxSearchTerms  = 8
+	maxSearchLength = 200
+)
+
+type SearchUsecase interface {
+	Search(viewer Requester, input SearchInput) (*SearchResult, error)
+}
+
+type SearchInput struct {
+	Query           string
+	IncludeDialogue bool
+	Limit           int
+}
+
+type SearchResult struct {
+	Series   []domain.Series        `json:"series"`
+	Dialogue []domain.DialogueMatch `json:"dialogue,omitempty"`
+}
+
+type searchUsecase struct {
+	searchRepo domain.SearchRepository
+	userRepo   domain.UserRepository
+}
+
+func NewSearchUsecase(searchRepo domain.SearchRepository, userRepo domain.UserRepository) SearchUsecase {
+	return &searchUsecase{searchRepo, userRepo}
+}
+
+// Search matches series titles, subtitles, descriptions, authors and tag
+// names in English and Thai, and optionally chapter dialogue. Results follow
+// the same visibility and NSFW rules as the public catalogue.
+func (u *searchUsecase) Search(viewer Requester, input SearchInput) (*SearchResult, error) {
+	text := strings.TrimSpace(input.Query)
+	if text == "" {
+		return nil, fmt.Errorf("%w: search query is required", ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(text) > maxSearchLength {
+		return nil, fmt.Errorf("%w: search query is too long", ErrInvalidInput)
+	}
+
+	terms := strings.Fields(text)
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+
+	query := domain.SearchQuery{
+		Text:         text,
+		Terms:        terms,
+		Statuses:     listableStatuses,
+		Visibilities: []string{domain.VisibilityPublic},
+		Limit:        input.Limit,
+	}
+	if !viewerAllowsNSFW(u.userRepo, viewer) {
+		safeOnly := false
+		query.NSFW = &safeOnly
+	}
+	if query.Limit <= 0 {
+		query.Limit = domain.DefaultSeriesPageSize
+	}
+	if query.Limit > domain.MaxSeriesPageSize {
+		query.Limit = domain.MaxSeriesPageSize
+	}
+
+	series, err := u.searchRepo.SearchSeries(query)
+	if err != nil {
+		return nil, err
+	}
+	result := &SearchResult{Series: series}
+	if result.Series == nil {
+		result.Series = []domain.Series{}
+	}
+
+	if input.IncludeDialogue {
+		result.Dialogue, err = u.searchRepo.SearchDialogue(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
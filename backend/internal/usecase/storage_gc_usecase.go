@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+)
+
+// DefaultStorageGCGrace protects recent files from garbage collection:
+// uploads are stored before the request that attaches them arrives, and
+// resumable chunks before their row is written.
+const DefaultStorageGCGrace = 24 * time.Hour
+
+// StorageGCReport describes one garbage collection pass. In a dry run
+// nothing is deleted and Deleted stays zero.
+type StorageGCReport struct {
+	DryRun  bool
+	Scanned int
+	// Garbage lists the unreferenced files older than the grace period.
+	Garbage      []domain.ObjectInfo
+	GarbageBytes int64
+	// Blobs counts the unreferenced blobs among the garbage.
+	Blobs   int
+	Deleted int
+}
+
+type StorageGCUsecase interface {
+	// CollectGarbage finds stored files that no series, chapter page, blob
+	// or upload in progress refers to and deletes those last modified more
+	// than grace ago. Users have no stored files of their own.
+	CollectGarbage(grace time.Duration, dryRun bool) (*StorageGCReport, error)
+}
+
+type storageGCUsecase struct {
+	storage  domain.FileStorage
+	blobRepo domain.BlobRepository
+}
+
+func NewStorageGCUsecase(storage domain.FileStorage, blobRepo domain.BlobRepository) StorageGCUsecase {
+	return &storageGCUsecase{storage, blobRepo}
+}
+
+func (u *storageGCUsecase) CollectGarbage(grace time.Duration, dryRun bool) (*StorageGCReport, error) {
+	cutoff := time.Now().Add(-grace)
+	report := &StorageGCReport{DryRun: dryRun}
+
+	// Blob files are referenced by their own blob row, so blobs nobody
+	// uses any more are collected as a whole.
+	blobs, err := u.blobRepo.ListUnreferencedBlobs(cutoff)
+	if err != nil {
+		return nil, err
+	}
+	blobKeys := make(map[string]bool)
+	for i := range blobs {
+		for _, k := range blobs[i].StorageKeys() {
+			blobKeys[k] = true
+		}
+	}
+	report.Blobs = len(blobs)
+
+	referenced, err := u.referencedKeys()
+	if err != nil {
+		return nil, err
+	}
+	err = u.storage.List("", func(obj domain.ObjectInfo) error {
+		report.Scanned++
+		inUse := referenced[obj.Key] && !blobKeys[obj.Key]
+		if inUse || !obj.ModTime.Before(cutoff) {
+			return nil
+		}
+		report.Garbage = append(report.Garbage, obj)
+		report.GarbageBytes += obj.Size
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	for i := range blobs {
+		_, err := u.blobRepo.DeleteBlobIfUnreferenced(blobs[i].Hash, cutoff, func(blob *domain.Blob) error {
+			for _, k := range blob.StorageKeys() {
+				if err := u.storage.Delete(k); err != nil {
+					return err
+				}
+				report.Deleted++
+			}
+			return nil
+		})
+		if err != nil {
+			return report, err
+		}
+	}
+
+	// Look again right before deleting, in case something picked up one
+	// of the files while storage was being listed.
+	referenced, err = u.referencedKeys()
+	if err != nil {
+		return report, err
+	}
+	for _, obj := range report.Garbage {
+		if blobKeys[obj.Key] || referenced[obj.Key] {
+			continue
+		}
+		if err := u.storage.Delete(obj.Key); err != nil {
+			return report, err
+		}
+		report.Deleted++
+	}
+	return report, nil
+}
+
+// referencedKeys collects the keys the database points at. URLs left over
+// from before asset keys also protect the file name they end in, since
+// they may have been served from a base URL that is no longer configured.
+func (u *storageGCUsecase) referencedKeys() (map[string]bool, error) {
+	values, err := u.blobRepo.ListReferencedKeys()
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool, len(values))
+	for _, v := range values {
+		key := domain.AssetKeyFromURL(v)
+		keys[string(key)] = true
+		if key.IsExternal() {
+			if parsed, err := url.Parse(v); err == nil {
+				if p, err := url.PathUnescape(parsed.Path); err == nil {
+					keys[path.Base(p)] = true
+					keys[strings.TrimPrefix(p, "/uploads/")] = true
+				}
+			}
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+)
+
+type TagUsecase interface {
+	ListTags() ([]domain.Tag, error)
+	// ResolveTags finds or creates the canonical tag for each name, so the
+	// same tag is reused across series.
+	ResolveTags(names []domain.MultilingualText) ([]domain.Tag, error)
+	MergeTags(sourceID, targetID uuid.UUID) error
+	AddAlias(tagID uuid.UUID, alias string) (*domain.TagAlias, error)
+}
+
+type tagUsecase struct {
+	tagRepo domain.TagRepository
+}
+
+func NewTagUsecase(tagRepo domain.TagRepository) TagUsecase {
+	return &tagUsecase{tagRepo}
+}
+
+func (u *tagUsecase) ListTags() ([]domain.Tag, error) {
+	return u.tagRepo.ListTags()
+}
+
+func (u *tagUsecase) ResolveTags(names []domain.MultilingualText) ([]domain.Tag, error) {
+	tags := make([]domain.Tag, 0, len(names))
+	seen := make(map[uuid.UUID]bool, len(names))
+	for _, name := range names {
+		tag, err := u.resolveTag(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag.ID] {
+			continue
+		}
+		seen[tag.ID] = true
+		tags = append(tags, *tag)
+	}
+	return tags, nil
+}
+
+// resolveTag looks a tag up by the slug of its English name (or Thai name if
+// there is no English one), creating it if needed and filling in any
+// translation the existing tag is missing.
+func (u *tagUsecase) resolveTag(name domain.MultilingualText) (*domain.Tag, error) {
+	source := name.En
+	if strings.TrimSpace(source) == "" {
+		source = name.Th
+	}
+	slug := slugify(source)
+	if slug == "" {
+		return nil, fmt.Errorf("%w: tag %q has no usable name", ErrInvalidInput, source)
+	}
+
+	tag, err := u.tagRepo.FindTagBySlug(slug)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		tag = newTag(slug, name)
+		if err = u.tagRepo.CreateTag(tag); err != nil {
+			// Another request may have created the same slug meanwhile.
+			if existing, findErr := u.tagRepo.FindTagBySlug(slug); findErr == nil {
+				return existing, nil
+			}
+			return nil, err
+		}
+		return tag, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range tagNames(name) {
+		if hasTranslation(tag, n.lang) {
+			continue
+		}
+		translation := domain.TagTranslation{ID: uuid.New(), TagID: tag.ID, Language: n.lang, Name: n.text}
+		if err := u.tagRepo.AddTranslation(&translation); err != nil {
+			return nil, err
+		}
+		tag.Translations = append(tag.Translations, translation)
+	}
+	return tag, nil
+}
+
+func (u *tagUsecase) MergeTags(sourceID, targetID uuid.UUID) error {
+	if sourceID == targetID {
+		return fmt.Errorf("%w: cannot merge a tag into itself", ErrInvalidInput)
+	}
+	return notFound(u.tagRepo.MergeTags(sourceID, targetID))
+}
+
+func (u *tagUsecase) AddAlias(tagID uuid.UUID, alias string) (*domain.TagAlias, error) {
+	if _, err := u.tagRepo.GetTagByID(tagID); err != nil {
+		return nil, notFound(err)
+	}
+
+	slug := slugify(alias)
+	if slug == "" {
+		return nil, fmt.Errorf("%w: alias %q has no usable characters", ErrInvalidInput, alias)
+	}
+	if _, err := u.tagRepo.FindTagBySlug(slug); err == nil {
+		return nil, fmt.Errorf("%w: slug %q is already in use", ErrConflict, slug)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	tagAlias := &domain.TagAlias{
+		ID:        uuid.New(),
+		TagID:     tagID,
+		Slug:      slug,
+		CreatedAt: time.Now(),
+	}
+	if err := u.tagRepo.CreateAlias(tagAlias); err != nil {
+		return nil, err
+	}
+	return tagAlias, nil
+}
+
+func newTag(slug string, name domain.MultilingualText) *domain.Tag {
+	tagID := uuid.New()
+	tag := &domain.Tag{
+		ID:        tagID,
+		Slug:      slug,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	for _, n := range tagNames(name) {
+		tag.Translations = append(tag.Translations, domain.TagTranslation{
+			ID:       uuid.New(),
+			TagID:    tagID,
+			Language: n.lang,
+			Name:     n.text,
+		})
+	}
+	return tag
+}
+
+type tagName struct {
+	lang string
+	text string
+}
+
+// tagNames lists the non-empty translations of a submitted tag name.
+func tagNames(name domain.MultilingualText) []tagName {
+	var names []tagName
+	if strings.TrimSpace(name.En) != "" {
+		names = append(names, tagName{"en", name.En})
+	}
+	if strings.TrimSpace(name.Th) != "" {
+		names = append(names, tagName{"th", name.Th})
+	}
+	return names
+}
+
+func hasTranslation(tag *domain.Tag, lang string) bool {
+	for _, t := range tag.Translations {
+		if t.Language == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// slugify lower-cases s and joins its words with hyphens. Letters from any
+// script are kept, so Thai-only names get Thai slugs; accents are stripped
+// from Latin letters but Thai vowel and tone marks are preserved.
+func slugify(s string) string {
+	var b strings.Builder
+	var prev rune
+	pendingHyphen := false
+	for _, r := range norm.NFD.String(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Drop accents on Latin letters; keep combining marks that
+			// belong to other scripts (e.g. Thai).
+			if prev != 0 && !unicode.Is(unicode.Latin, prev) {
+				b.WriteRune(r)
+			}
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			pendingHyphen = true
+		}
+		prev = r
+	}
+	return norm.NFC.String(b.String())
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"github.com/google/uuid"
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+)
+
+// TileBackfillReport describes one tile backfill pass. In a dry run no
+// tiles are rendered and Tiled lists the pages that would be.
+type TileBackfillReport struct {
+	DryRun bool
+	Tiled  []domain.ChapterImage
+	Failed []TileBackfillFailure
+}
+
+type TileBackfillFailure struct {
+	ImageID uuid.UUID
+	Err     error
+}
+
+type TileBackfillUsecase interface {
+	// BackfillTiles tiles the tall chapter pages that were stored before
+	// pages were tiled. A page that fails is reported and skipped.
+	BackfillTiles(dryRun bool) (*TileBackfillReport, error)
+}
+
+type tileBackfillUsecase struct {
+	comicRepo     domain.ComicRepository
+	uploadUsecase UploadUsecase
+}
+
+func NewTileBackfillUsecase(comicRepo domain.ComicRepository, uploadUsecase UploadUsecase) TileBackfillUsecase {
+	return &tileBackfillUsecase{comicRepo, uploadUsecase}
+}
+
+func (u *tileBackfillUsecase) BackfillTiles(dryRun bool) (*TileBackfillReport, error) {
+	images, err := u.comicRepo.ListUntiledChapterImages(tileThreshold)
+	if err != nil {
+		return nil, err
+	}
+	report := &TileBackfillReport{DryRun: dryRun}
+	for _, img := range images {
+		if dryRun {
+			report.Tiled = append(report.Tiled, img)
+			continue
+		}
+		tiles, err := u.uploadUsecase.TileImage(img.ImageURL)
+		if err == nil {
+			err = u.comicRepo.UpdateChapterImageTiles(img.ID, tiles)
+		}
+		if err != nil {
+			report.Failed = append(report.Failed, TileBackfillFailure{img.ID, err})
+			continue
+		}
+		img.Tiles = tiles
+		report.Tiled = append(report.Tiled, img)
+	}
+	return report, nil
+}
@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"mime"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pur108/ebook-platform/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+var allowedImageExts = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".webp": true,
+	".gif":  true,
+}
+
+// releaseGracePeriod is how long a freshly uploaded image is protected from
+// ReleaseImages: uploads are attached to a series or chapter by a later
+// request.
+const releaseGracePeriod = 24 * time.Hour
+
+// FileUpload is a file received from a client, not yet stored.
+type FileUpload struct {
+	Filename string
+	Reader   io.Reader
+}
+
+type UploadUsecase interface {
+	// SaveImage validates an uploaded image, strips its metadata and stores
+	// it together with a thumbnail and downscaled reader variants.
+	SaveImage(filename string, r io.Reader) (*StoredImage, error)
+	// ReleaseImages deletes the stored files behind keys that nothing
+	// references any more. Images uploaded within releaseGracePeriod are
+	// kept, since their uploader may not have attached them yet, and are
+	// not retried: the storage GC job or cmd/gc removes them later.
+	ReleaseImages(keys []domain.AssetKey) error
+	// Open reads back an image previously stored by SaveImage.
+	Open(key domain.AssetKey) (io.ReadCloser, error)
+	// TileImage returns the tiles of a stored image, rendering and storing
+	// them first if it is tall and was stored before pages were tiled.
+	TileImage(key domain.AssetKey) (domain.ImageTiles, error)
+}
+
+type uploadUsecase struct {
+	storage  domain.FileStorage
+	blobRepo domain.BlobRepository
+	limits   UploadLimits
+}
+
+func NewUploadUsecase(storage domain.FileStorage, blobRepo domain.BlobRepository, limits UploadLimits) UploadUsecase {
+	return &uploadUsecase{storage, blobRepo, limits}
+}
+
+// SaveImage stores images content-addressed: files are named after the
+// SHA-256 of the metadata-stripped original, and content that is already
+// stored is not processed or written again.
+func (u *uploadUsecase) SaveImage(filename string, r io.Reader) (*StoredImage, error) {
+	// Cheap early rejection; the content itself is checked by validateImage
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !allowedImageExts[ext] {
+		return nil, uploadError(UploadCodeUnsupportedType, "invalid file type, only images are allowed")
+	}
+
+	data, err := readLimited(r, u.limits.MaxBytes)
+	if err != nil {
+		return nil, err
+	}
+	img, err := validateImage(data, u.limits)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(img.original)
+	hash := hex.EncodeToString(sum[:])
+	blob, err := u.blobRepo.TouchBlob(hash)
+	if err == nil {
+		// Content stored before pages were tiled gets its tiles now.
+		if blob.Height > tileThreshold && len(blob.Tiles) == 0 {
+			if err := u.tileBlob(blob, img.original); err != nil {
+				return nil, err
+			}
+		}
+		return newStoredImage(blob), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	rendered, err := renderVariants(img)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	blob = &domain.Blob{
+		Hash:      hash,
+		Size:      int64(len(img.original)),
+		Width:     img.width,
+		Height:    img.height,
+		Variants:  make(domain.ImageVariants, 0, len(rendered.variants)),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if blob.OriginalKey, err = u.writeFile(hash+img.ext, img.original); err != nil {
+		return nil, err
+	}
+	thumb := rendered.thumbnail
+	if blob.ThumbnailKey, err = u.writeFile(hash+"_thumb"+thumb.ext, thumb.data); err != nil {
+		return nil, err
+	}
+	for _, v := range rendered.variants {
+		key, err := u.writeFile(fmt.Sprintf("%s_w%d%s", hash, v.width, v.ext), v.data)
+		if err != nil {
+			return nil, err
+		}
+		blob.Variants = append(blob.Variants, domain.ImageVariant{Width: v.width, Height: v.height, URL: key})
+	}
+	if blob.Tiles, err = u.writeTiles(hash, rendered.tiles); err != nil {
+		return nil, err
+	}
+	if err := u.blobRepo.SaveBlob(blob); err != nil {
+		return nil, err
+	}
+	return newStoredImage(blob), nil
+}
+
+func (u *uploadUsecase) ReleaseImages(keys []domain.AssetKey) error {
+	seen := make(map[string]bool)
+	uploadedBefore := time.Now().Add(-releaseGracePeriod)
+	for _, key := range keys {
+		hash, ok := blobHash(key)
+		if !ok || seen[hash] {
+			continue
+		}
+		seen[hash] = true
+
+		_, err := u.blobRepo.DeleteBlobIfUnreferenced(hash, uploadedBefore, func(blob *domain.Blob) error {
+			for _, k := range blob.StorageKeys() {
+				if err := u.storage.Delete(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blobHash extracts the content hash from a content-addressed key such as
+// "<hash>.png" or "<hash>_w480.jpg". Keys from before content addressing,
+// and external URLs, have none.
+func blobHash(key domain.AssetKey) (string, bool) {
+	if key.IsExternal() {
+		return "", false
+	}
+	name := path.Base(string(key))
+	if len(name) < sha256.Size*2 {
+		return "", false
+	}
+	hash := name[:sha256.Size*2]
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", false
+	}
+	return hash, true
+}
+
+// writeFile stores data under key.
+func (u *uploadUsecase) writeFile(key string, data []byte) (domain.AssetKey, error) {
+	contentType := mime.TypeByExtension(filepath.Ext(key))
+	if err := u.storage.Put(key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return "", err
+	}
+	return domain.AssetKey(key), nil
+}
+
+// writeTiles stores tiles as "<stem>_t<i>.ext".
+func (u *uploadUsecase) writeTiles(stem string, tiles []encodedTile) (domain.ImageTiles, error) {
+	var out domain.ImageTiles
+	for i, t := range tiles {
+		key, err := u.writeFile(fmt.Sprintf("%s_t%d%s", stem, i, t.ext), t.data)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, domain.ImageTile{Y: t.y, Width: t.width, Height: t.height, URL: key})
+	}
+	return out, nil
+}
+
+// tileOriginal renders and stores the tiles of an image's original data.
+func (u *uploadUsecase) tileOriginal(stem string, data []byte) (domain.ImageTiles, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, uploadError(UploadCodeCorrupt, "image data is corrupt: %v", err)
+	}
+	tiles, err := renderTiles(img)
+	if err != nil {
+		return nil, err
+	}
+	return u.writeTiles(stem, tiles)
+}
+
+// tileBlob adds tiles to a blob stored before pages were tiled.
+func (u *uploadUsecase) tileBlob(blob *domain.Blob, original []byte) error {
+	tiles, err := u.tileOriginal(blob.Hash, original)
+	if err != nil {
+		return err
+	}
+	if err := u.blobRepo.UpdateBlobTiles(blob.Hash, tiles); err != nil {
+		return err
+	}
+	blob.Tiles = tiles
+	return nil
+}
+
+func (u *uploadUsecase) TileImage(key domain.AssetKey) (domain.ImageTiles, error) {
+	var blob *domain.Blob
+	if hash, ok := blobHash(key); ok {
+		b, err := u.blobRepo.GetBlob(hash)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if b != nil && len(b.Tiles) > 0 {
+			return b.Tiles, nil
+		}
+		blob = b
+	}
+
+	rc, err := u.Open(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return nil, err
+	}
+	if blob != nil {
+		if err := u.tileBlob(blob, data); err != nil {
+			return nil, err
+		}
+		return blob.Tiles, nil
+	}
+	// Files from before content addressing have no blob; their tiles are
+	// named after the file.
+	return u.tileOriginal(strings.TrimSuffix(string(key), path.Ext(string(key))), data)
+}
+
+func (u *uploadUsecase) Open(key domain.AssetKey) (io.ReadCloser, error) {
+	if key == "" || key.IsExternal() {
+		return nil, fmt.Errorf("%w: %s is not a stored upload", ErrNotFound, key)
+	}
+	rc, err := u.storage.Get(string(key))
+	if errors.Is(err, domain.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc, nil
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Upload error codes, returned to clients alongside the message so they can
+// tell failures apart without parsing it.
+const (
+	UploadCodeEmpty            = "empty_file"
+	UploadCodeTooLarge         = "file_too_large"
+	UploadCodeUnsupportedType  = "unsupported_type"
+	UploadCodeCorrupt          = "corrupt_image"
+	UploadCodeDimensionsTooBig = "dimensions_too_large"
+	UploadCodeTooManyPixels    = "too_many_pixels"
+	UploadCodeAnimated         = "animated_not_allowed"
+)
+
+// UploadError is an upload rejected by validation. It matches ErrInvalidInput
+// under errors.Is.
+type UploadError struct {
+	Code    string
+	Message string
+}
+
+func (e *UploadError) Error() string {
+	return ErrInvalidInput.Error() + ": " + e.Message
+}
+
+func (e *UploadError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+func uploadError(code, format string, args ...interface{}) *UploadError {
+	return &UploadError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// UploadLimits bounds what SaveImage accepts.
+type UploadLimits struct {
+	MaxBytes  int64
+	MaxWidth  int
+	MaxHeight int
+	// MaxPixels guards against decompression bombs: small files that
+	// decode to enormous bitmaps. It is checked from the header, before
+	// any pixel data is decoded.
+	MaxPixels     int
+	AllowAnimated bool
+}
+
+// DefaultUploadLimits leaves room for long webtoon strips, which are narrow
+// but can be tens of thousands of pixels tall.
+func DefaultUploadLimits() UploadLimits {
+	return UploadLimits{
+		MaxBytes:  50 << 20,
+		MaxWidth:  10000,
+		MaxHeight: 60000,
+		MaxPixels: 100_000_000,
+	}
+}
+
+// UploadLimitsFromEnv reads UPLOAD_MAX_BYTES, UPLOAD_MAX_WIDTH,
+// UPLOAD_MAX_HEIGHT, UPLOAD_MAX_PIXELS and UPLOAD_ALLOW_ANIMATED over the
+// defaults, so the server and the command-line tools accept the same pages.
+func UploadLimitsFromEnv() (UploadLimits, error) {
+	limits := DefaultUploadLimits()
+	for _, v := range []struct {
+		key string
+		dst *int
+	}{
+		{"UPLOAD_MAX_WIDTH", &limits.MaxWidth},
+		{"UPLOAD_MAX_HEIGHT", &limits.MaxHeight},
+		{"UPLOAD_MAX_PIXELS", &limits.MaxPixels},
+	} {
+		if err := envPositiveInt(v.key, v.dst); err != nil {
+			return UploadLimits{}, err
+		}
+	}
+	maxBytes := int(limits.MaxBytes)
+	if err := envPositiveInt("UPLOAD_MAX_BYTES", &maxBytes); err != nil {
+		return UploadLimits{}, err
+	}
+	limits.MaxBytes = int64(maxBytes)
+	if v := os.Getenv("UPLOAD_ALLOW_ANIMATED"); v != "" {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return UploadLimits{}, fmt.Errorf("invalid UPLOAD_ALLOW_ANIMATED %q", v)
+		}
+		limits.AllowAnimated = allow
+	}
+	return limits, nil
+}
+
+// envPositiveInt overwrites *dst with the environment variable key when it
+// is set.
+func envPositiveInt(key string, dst *int) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("invalid %s %q", key, v)
+	}
+	*dst = n
+	return nil
+}
+
+// readLimited reads r, failing once it exceeds max bytes rather than
+// buffering the whole stream.
+func readLimited(r io.Reader, max int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > max {
+		return nil, uploadError(UploadCodeTooLarge, "file exceeds the %d byte limit", max)
+	}
+	if len(data) == 0 {
+		return nil, uploadError(UploadCodeEmpty, "file is empty")
+	}
+	return data, nil
+}
+
+// sniffImageFormat identifies an image by its magic bytes, using the names
+// image.Decode reports. It returns "" for anything else.
+func sniffImageFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return "jpeg"
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return "png"
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return "gif"
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return "webp"
+	}
+	return ""
+}
+
+// isAnimated reports whether data holds more than one frame. Only the
+// container is inspected; frames are not decoded.
+func isAnimated(format string, data []byte) bool {
+	switch format {
+	case "gif":
+		return gifFrameCount(data) > 1
+	case "png":
+		// APNG announces itself with an acTL chunk before the image data.
+		for i := 8; i+8 <= len(data); {
+			length := int(binary.BigEndian.Uint32(data[i:]))
+			switch string(data[i+4 : i+8]) {
+			case "acTL":
+				return true
+			case "IDAT":
+				return false
+			}
+			i += 12 + length
+		}
+	case "webp":
+		// The VP8X header flags animation, and animated files carry ANIM.
+		for i := 12; i+8 <= len(data); {
+			size := int(binary.LittleEndian.Uint32(data[i+4:]))
+			switch string(data[i : i+4]) {
+			case "VP8X":
+				if i+9 <= len(data) && data[i+8]&0x02 != 0 {
+					return true
+				}
+			case "ANIM", "ANMF":
+				return true
+			}
+			i += 8 + size + size%2
+		}
+	}
+	return false
+}
+
+// gifFrameCount walks the GIF block structure counting image descriptors.
+// It stops early at two, which is all isAnimated needs.
+func gifFrameCount(data []byte) int {
+	if len(data) < 13 {
+		return 0
+	}
+	i := 13
+	if flags := data[10]; flags&0x80 != 0 {
+		i += 3 << (flags&0x07 + 1)
+	}
+
+	skipSubBlocks := func(i int) int {
+		for i < len(data) {
+			n := int(data[i])
+			i++
+			if n == 0 {
+				return i
+			}
+			i += n
+		}
+		return len(data)
+	}
+
+	frames := 0
+	for i < len(data) && frames < 2 {
+		switch data[i] {
+		case 0x2C: // image descriptor
+			frames++
+			if i+10 > len(data) {
+				return frames
+			}
+			flags := data[i+9]
+			i += 10
+			if flags&0x80 != 0 {
+				i += 3 << (flags&0x07 + 1)
+			}
+			i++ // LZW minimum code size
+			i = skipSubBlocks(i)
+		case 0x21: // extension
+			i = skipSubBlocks(i + 2)
+		default: // trailer or garbage
+			return frames
+		}
+	}
+	return frames
+}
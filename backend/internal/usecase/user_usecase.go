@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pur108/ebook-platform/backend/internal/domain"
@@ -10,6 +12,8 @@ import (
 type UserUsecase interface {
 	GetProfile(id uuid.UUID) (*domain.User, error)
 	BecomeCreator(id uuid.UUID) error
+	ConfirmAge(id uuid.UUID, birthDate time.Time) (*domain.User, error)
+	UpdateContentPreferences(id uuid.UUID, showNSFW bool) (*domain.User, error)
 }
 
 type userUsecase struct {
@@ -37,3 +41,40 @@ func (u *userUsecase) BecomeCreator(id uuid.UUID) error {
 	user.Role = domain.RoleCreator
 	return u.userRepo.Update(user)
 }
+
+// ConfirmAge records that the user is old enough for NSFW content. Only the
+// confirmation time is stored, not the birth date.
+func (u *userUsecase) ConfirmAge(id uuid.UUID, birthDate time.Time) (*domain.User, error) {
+	user, err := u.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if birthDate.AddDate(domain.MinimumNSFWAge, 0, 0).After(now) {
+		return nil, fmt.Errorf("you must be at least %d years old", domain.MinimumNSFWAge)
+	}
+
+	user.AgeConfirmedAt = &now
+	if err := u.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *userUsecase) UpdateContentPreferences(id uuid.UUID, showNSFW bool) (*domain.User, error) {
+	user, err := u.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if showNSFW && user.AgeConfirmedAt == nil {
+		return nil, errors.New("confirm your age before enabling NSFW content")
+	}
+
+	user.ShowNSFW = showNSFW
+	if err := u.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
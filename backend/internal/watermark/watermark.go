@@ -0,0 +1,196 @@
+// Package watermark marks page images with the ID of the reader they were
+// served to, so leaked copies can be traced back to an account.
+//
+// The invisible mark is a keyed spread-spectrum pattern: each 2x2 pixel
+// cell nudges its brightness up or down by a few levels, and the pattern of
+// nudges encodes the user ID and a checksum across the whole image. It is
+// read back without the original by correlating the image's fine detail
+// with the same pattern. It survives JPEG recompression, but not resizing or
+// cropping.
+package watermark
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/google/uuid"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// ErrNoWatermark means no mark made with the given secret was found.
+var ErrNoWatermark = errors.New("no watermark found")
+
+const (
+	cellSize = 2
+	// strength is how many brightness levels each cell moves by.
+	strength = 3
+	// payloadBits is the user ID followed by a 32-bit checksum.
+	payloadBits = (16 + 4) * 8
+	// minCellsPerBit keeps images too small to hold a reliable mark from
+	// decoding to a random ID.
+	minCellsPerBit = 64
+)
+
+// Options selects which marks Embed applies.
+type Options struct {
+	// Visible stamps a short reader code in the bottom-right corner.
+	Visible bool
+	// Invisible embeds the full user ID in the pixels.
+	Invisible bool
+}
+
+// Embed returns a copy of img marked for userID. secret keys the invisible
+// pattern; Decode needs the same one.
+func Embed(img image.Image, userID uuid.UUID, secret []byte, opts Options) *image.NRGBA {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+
+	if opts.Invisible {
+		bits := payload(userID)
+		seed := seedFrom(secret)
+		for y := 0; y < b.Dy(); y++ {
+			for x := 0; x < b.Dx(); x++ {
+				bit, sign := cellBit(seed, x/cellSize, y/cellSize)
+				delta := strength * sign
+				if bits[bit/8]&(1<<(bit%8)) == 0 {
+					delta = -delta
+				}
+				i := dst.PixOffset(x, y)
+				for c := 0; c < 3; c++ {
+					dst.Pix[i+c] = clamp(int(dst.Pix[i+c]) + delta)
+				}
+			}
+		}
+	}
+	if opts.Visible {
+		stamp(dst, "#"+userID.String()[:8])
+	}
+	return dst
+}
+
+// Decode reads the user ID from an image marked by Embed with secret.
+func Decode(img image.Image, secret []byte) (uuid.UUID, error) {
+	b := img.Bounds()
+	cw, ch := b.Dx()/cellSize, b.Dy()/cellSize
+	if cw < 3 || ch < 3 || (cw-2)*(ch-2) < payloadBits*minCellsPerBit {
+		return uuid.Nil, ErrNoWatermark
+	}
+
+	// Average brightness per cell.
+	luma := make([]float64, cw*ch)
+	for cy := 0; cy < ch; cy++ {
+		for cx := 0; cx < cw; cx++ {
+			var sum float64
+			for dy := 0; dy < cellSize; dy++ {
+				for dx := 0; dx < cellSize; dx++ {
+					r, g, bl, _ := img.At(b.Min.X+cx*cellSize+dx, b.Min.Y+cy*cellSize+dy).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)
+				}
+			}
+			luma[cy*cw+cx] = sum / (cellSize * cellSize)
+		}
+	}
+
+	// Correlate each cell's difference from its neighbours, which keeps the
+	// mark and drops most of the artwork, with the pattern.
+	seed := seedFrom(secret)
+	var scores [payloadBits]float64
+	for cy := 1; cy < ch-1; cy++ {
+		for cx := 1; cx < cw-1; cx++ {
+			var around float64
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if dx != 0 || dy != 0 {
+						around += luma[(cy+dy)*cw+cx+dx]
+					}
+				}
+			}
+			detail := luma[cy*cw+cx] - around/8
+			bit, sign := cellBit(seed, cx, cy)
+			scores[bit] += float64(sign) * detail
+		}
+	}
+
+	var bits [payloadBits / 8]byte
+	for i, s := range scores {
+		if s > 0 {
+			bits[i/8] |= 1 << (i % 8)
+		}
+	}
+	id, err := uuid.FromBytes(bits[:16])
+	if err != nil || bits != payload(id) {
+		return uuid.Nil, ErrNoWatermark
+	}
+	return id, nil
+}
+
+func payload(id uuid.UUID) [payloadBits / 8]byte {
+	var out [payloadBits / 8]byte
+	copy(out[:], id[:])
+	sum := sha256.Sum256(id[:])
+	copy(out[16:], sum[:4])
+	return out
+}
+
+func seedFrom(secret []byte) uint64 {
+	sum := sha256.Sum256(append([]byte("watermark:"), secret...))
+	return binary.LittleEndian.Uint64(sum[:])
+}
+
+// cellBit picks which payload bit a cell carries and the direction of its
+// nudge, from a hash of its position.
+func cellBit(seed uint64, cx, cy int) (int, int) {
+	h := splitmix64(seed ^ uint64(cx)<<32 ^ uint64(cy))
+	sign := 1
+	if h>>63 == 1 {
+		sign = -1
+	}
+	return int(h % payloadBits), sign
+}
+
+func splitmix64(x uint64) uint64 {
+	x += 0x9E3779B97F4A7C15
+	x = (x ^ x>>30) * 0xBF58476D1CE4E5B9
+	x = (x ^ x>>27) * 0x94D049BB133111EB
+	return x ^ x>>31
+}
+
+func clamp(v int) uint8 {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
+
+// stamp writes text in the bottom-right corner, light on a dark outline so
+// it reads on any background.
+func stamp(dst *image.NRGBA, text string) {
+	face := basicfont.Face7x13
+	const margin = 8
+	width := font.MeasureString(face, text).Ceil()
+	x := dst.Bounds().Dx() - width - margin
+	y := dst.Bounds().Dy() - margin
+	if x < 0 || y < face.Ascent {
+		return
+	}
+
+	d := &font.Drawer{Dst: dst, Face: face}
+	d.Src = image.NewUniform(color.NRGBA{0, 0, 0, 160})
+	for _, off := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+		d.Dot = fixed.P(x+off[0], y+off[1])
+		d.DrawString(text)
+	}
+	d.Src = image.NewUniform(color.NRGBA{255, 255, 255, 200})
+	d.Dot = fixed.P(x, y)
+	d.DrawString(text)
+}
@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+// RunPublisher publishes scheduled series and chapters every interval until
+// ctx is cancelled. It runs once immediately so anything that fell due while
+// the server was down is caught up on start.
+func RunPublisher(ctx context.Context, comicUsecase usecase.ComicUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		seriesCount, chapterCount, err := comicUsecase.PublishScheduled(time.Now())
+		if err != nil {
+			log.Println("Scheduled publish failed:", err)
+		} else if seriesCount > 0 || chapterCount > 0 {
+			log.Printf("Published %d scheduled series and %d scheduled chapters", seriesCount, chapterCount)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+// RunStorageGC deletes unreferenced files older than grace every interval
+// until ctx is cancelled. In a dry run it only logs what it found.
+func RunStorageGC(ctx context.Context, gcUsecase usecase.StorageGCUsecase, interval, grace time.Duration, dryRun bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := gcUsecase.CollectGarbage(grace, dryRun)
+		if err != nil {
+			log.Println("Storage garbage collection failed:", err)
+		} else if len(report.Garbage) > 0 {
+			if dryRun {
+				log.Printf("Found %d unreferenced files (%d bytes); dry run, nothing deleted", len(report.Garbage), report.GarbageBytes)
+			} else {
+				log.Printf("Deleted %d unreferenced files (%d bytes found)", report.Deleted, report.GarbageBytes)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
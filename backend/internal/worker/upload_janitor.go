@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/pur108/ebook-platform/backend/internal/usecase"
+)
+
+// RunUploadJanitor discards abandoned resumable uploads, and the chunks
+// they left in storage, every interval until ctx is cancelled.
+func RunUploadJanitor(ctx context.Context, resumableUsecase usecase.ResumableUploadUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := resumableUsecase.ExpireUploads(time.Now())
+		if err != nil {
+			log.Println("Expiring uploads failed:", err)
+		} else if removed > 0 {
+			log.Printf("Discarded %d expired uploads", removed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
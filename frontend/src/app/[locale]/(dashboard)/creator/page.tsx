@@ -7,9 +7,10 @@ import Link from 'next/link';
 
 interface Series {
     id: string;
-    title: string;
+    title: { en: string; th: string };
     thumbnail_url: string;
     status: string;
+    chapter_count: number;
 }
 
 export default function CreatorDashboard() {
@@ -18,11 +19,10 @@ export default function CreatorDashboard() {
 
     useEffect(() => {
         if (user) {
-            // Fetch creator's series
-            // Note: We need an endpoint for this. For now, we might not have it implemented in backend yet.
-            // Let's assume we have GET /api/creator/series or similar.
-            // Actually, we implemented GET /api/series/:id but not list.
-            // I'll skip fetching for now or just show empty state.
+            // Fetch creator's series, including drafts and private ones
+            api.get('/creator/series')
+                .then((res) => setSeries(res.data))
+                .catch((err) => console.error('Failed to fetch series', err));
         }
     }, [user]);
 
@@ -57,9 +57,9 @@ export default function CreatorDashboard() {
                 ) : (
                     series.map((s) => (
                         <div key={s.id} className="border rounded p-4">
-                            <img src={s.thumbnail_url} alt={s.title} className="w-full h-48 object-cover mb-4 rounded" />
-                            <h3 className="font-bold text-xl">{s.title}</h3>
-                            <p className="text-sm text-gray-500 capitalize">{s.status}</p>
+                            <img src={s.thumbnail_url} alt={s.title.en || s.title.th} className="w-full h-48 object-cover mb-4 rounded" />
+                            <h3 className="font-bold text-xl">{s.title.en || s.title.th}</h3>
+                            <p className="text-sm text-gray-500 capitalize">{s.status} · {s.chapter_count} chapters</p>
                         </div>
                     ))
                 )}
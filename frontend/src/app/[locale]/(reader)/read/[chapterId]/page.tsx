@@ -21,9 +21,26 @@ interface Translation {
     translated_text: string;
 }
 
+interface ImageVariant {
+    width: number;
+    height: number;
+    url: string;
+}
+
+interface ImageTile {
+    y: number;
+    width: number;
+    height: number;
+    url: string;
+}
+
 interface ChapterImage {
     id: string;
     image_url: string;
+    width: number;
+    height: number;
+    variants: ImageVariant[] | null;
+    tiles: ImageTile[] | null;
     order: number;
     text_layers: TextLayer[];
 }
@@ -39,6 +56,7 @@ export default function ReaderPage() {
     const [chapter, setChapter] = useState<Chapter | null>(null);
     const [language, setLanguage] = useState('original');
     const [isLoading, setIsLoading] = useState(true);
+    const [error, setError] = useState<string | null>(null);
 
     useEffect(() => {
         if (chapterId) {
@@ -48,6 +66,10 @@ export default function ReaderPage() {
                 })
                 .catch((err) => {
                     console.error(err);
+                    // Watermarked series ask readers to sign in first.
+                    if (err.response?.status === 403) {
+                        setError(err.response.data?.error || null);
+                    }
                 })
                 .finally(() => {
                     setIsLoading(false);
@@ -81,7 +103,7 @@ export default function ReaderPage() {
     };
 
     if (isLoading) return <div className="flex items-center justify-center h-screen">Loading...</div>;
-    if (!chapter) return <div className="flex items-center justify-center h-screen">Chapter not found</div>;
+    if (!chapter) return <div className="flex items-center justify-center h-screen">{error || "Chapter not found"}</div>;
 
     return (
         <div className="bg-gray-900 min-h-screen text-white">
@@ -123,7 +145,33 @@ export default function ReaderPage() {
             <div className="pt-16 pb-8 max-w-2xl mx-auto">
                 {chapter.images.sort((a, b) => a.order - b.order).map((image) => (
                     <div key={image.id} className="relative w-full">
-                        <img src={image.image_url} alt={`Page ${image.order}`} className="w-full h-auto block" />
+                        {image.tiles?.length ? (
+                            // Tall strips arrive as stacked tiles; together they span the
+                            // whole page, so text layer percentages still line up.
+                            image.tiles.map((tile, i) => (
+                                <img
+                                    key={tile.y}
+                                    src={tile.url}
+                                    width={tile.width}
+                                    height={tile.height}
+                                    loading={i === 0 ? undefined : "lazy"}
+                                    alt={i === 0 ? `Page ${image.order}` : ""}
+                                    className="w-full h-auto block"
+                                />
+                            ))
+                        ) : (
+                            <img
+                                src={image.image_url}
+                                srcSet={image.variants?.length
+                                    ? [...image.variants.map((v) => `${v.url} ${v.width}w`), `${image.image_url} ${image.width}w`].join(", ")
+                                    : undefined}
+                                sizes="(max-width: 672px) 100vw, 672px"
+                                width={image.width || undefined}
+                                height={image.height || undefined}
+                                alt={`Page ${image.order}`}
+                                className="w-full h-auto block"
+                            />
+                        )}
 
                         {/* Text Layers */}
                         {(image.text_layers || []).map((layer) => {
@@ -34,7 +34,7 @@ export default function Home() {
         const response = await fetch("http://localhost:8080/api/series");
         if (response.ok) {
           const data = await response.json();
-          setFeaturedSeries(data);
+          setFeaturedSeries(data.items);
         } else {
           console.error("Failed to fetch series");
         }